@@ -0,0 +1,305 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+SolveTridiagonal solves the n x n tridiagonal system A*x = b by the
+Thomas algorithm in O(n) operations. The matrix A is given by its
+diagonals: sub has the n-1 elements below the main diagonal, diag
+the n elements of the main diagonal and sup the n-1 elements above
+it, so that row r of A is
+
+	sub[r-1]*x[r-1] + diag[r]*x[r] + sup[r]*x[r+1] = b[r]
+
+The Thomas algorithm does not pivot, so it is only guaranteed to be
+stable for diagonally dominant matrices. A NotDiagonallyDominantError
+is returned if A is not (weakly) diagonally dominant, in this case
+use the pivoting factorization of Banded.LU. ErrSingular is returned
+if a pivot vanishes.
+*/
+func SolveTridiagonal(sub, diag, sup, b []float64) ([]float64, error) {
+	n := len(diag)
+	if len(b) != n || n > 0 && (len(sub) != n-1 || len(sup) != n-1) {
+		return nil, fmt.Errorf("tridiagonal system of order %d with diagonals of length %d, %d, %d and b of length %d: %w",
+			n, len(sub), len(diag), len(sup), len(b), ErrDimension)
+	}
+	if n == 0 {
+		return []float64{}, nil
+	}
+
+	for r := 0; r < n; r++ {
+		off := 0.
+		if r > 0 {
+			off += math.Abs(sub[r-1])
+		}
+		if r < n-1 {
+			off += math.Abs(sup[r])
+		}
+		if math.Abs(diag[r]) < off {
+			return nil, NotDiagonallyDominantError{r}
+		}
+	}
+
+	// Forward sweep: c holds the modified super-diagonal and x the
+	// modified right-hand side.
+	c := make([]float64, n)
+	x := make([]float64, n)
+	p := diag[0]
+	for r := 0; ; r++ {
+		if p == 0. {
+			return nil, ErrSingular
+		}
+		if r == 0 {
+			x[0] = b[0] / p
+		} else {
+			x[r] = (b[r] - sub[r-1]*x[r-1]) / p
+		}
+		if r == n-1 {
+			break
+		}
+		c[r] = sup[r] / p
+		p = diag[r+1] - sub[r]*c[r]
+	}
+
+	// Back substitution.
+	for r := n - 2; r >= 0; r-- {
+		x[r] -= c[r] * x[r+1]
+	}
+
+	return x, nil
+}
+
+// StartTridiagonal starts a n x n Banded matrix with one sub and one
+// super-diagonal from its diagonals, as in SolveTridiagonal. An
+// error is generated if the lengths of the diagonals do not match.
+func StartTridiagonal(sub, diag, sup []float64) (Banded, error) {
+	n := len(diag)
+	if n > 0 && (len(sub) != n-1 || len(sup) != n-1) {
+		return Banded{}, fmt.Errorf("tridiagonal matrix of order %d with diagonals of length %d and %d: %w",
+			n, len(sub), len(sup), ErrDimension)
+	}
+
+	m, _ := StartBanded(n, n, 1, 1)
+	for r := 0; r < n; r++ {
+		m.data[m.index(r, r)] = diag[r]
+		if r > 0 {
+			m.data[m.index(r, r-1)] = sub[r-1]
+		}
+		if r < n-1 {
+			m.data[m.index(r, r+1)] = sup[r]
+		}
+	}
+
+	return m, nil
+}
+
+// ToBanded returns the current matrix, m, in the compact storage of
+// a Banded matrix with kl sub-diagonals and ku super-diagonals. An
+// error is generated if m has a nonzero element out of the band.
+func (m Matrix) ToBanded(kl, ku int) (Banded, error) {
+	b, err := StartBanded(m.rows, m.cols, kl, ku)
+	if err != nil {
+		return Banded{}, err
+	}
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.row(r) {
+			if err := b.Set(r, c, v); err != nil {
+				return Banded{}, err
+			}
+		}
+	}
+
+	return b, nil
+}
+
+/*
+BandedLU holds the LU decomposition with partial pivoting of a
+square Banded matrix A with kl sub-diagonals and ku super-diagonals.
+Row interchanges widen the upper factor U to kl+ku super-diagonals,
+but the factorization keeps the compact storage, so it takes
+O(n*kl*(kl+ku)) operations and O(n*(kl+ku)) memory instead of the
+O(n³) and O(n²) of the dense LU.
+
+As in LAPACK, the interchanges are recorded step by step and L is
+kept as the multipliers of each step, which are applied to the
+right-hand side in the same order when solving.
+*/
+type BandedLU struct {
+	u    []float64
+	l    []float64
+	piv  []int
+	sign float64
+	tol  float64
+	n    int
+	kl   int
+	ku   int
+}
+
+// LU returns the LU decomposition with partial pivoting of the
+// banded matrix, m. A NotSquareError is returned if m is not a
+// square matrix. As in Matrix.LU, a singular matrix is only
+// reported by the methods that depend on the inverse of m.
+func (m Banded) LU() (*BandedLU, error) {
+	if m.rows != m.cols {
+		return nil, NotSquareError{m.rows, m.cols}
+	}
+
+	n, kl, ku := m.rows, m.kl, m.ku
+	f := &BandedLU{
+		u:    make([]float64, n*(2*kl+ku+1)),
+		l:    make([]float64, n*kl),
+		piv:  make([]int, n),
+		sign: 1.,
+		n:    n,
+		kl:   kl,
+		ku:   ku,
+	}
+
+	amax := 0.
+	for r := 0; r < n; r++ {
+		for c := max(0, r-kl); c <= min(n-1, r+ku); c++ {
+			v := m.data[m.index(r, c)]
+			f.u[f.index(r, c)] = v
+			amax = math.Max(amax, math.Abs(v))
+		}
+	}
+	f.tol = float64(n) * amax * epsilon
+
+	a := f.u
+	for k := 0; k < n; k++ {
+		last := min(n-1, k+kl)
+		cmax := min(n-1, k+kl+ku)
+
+		p := k
+		for i := k + 1; i <= last; i++ {
+			if math.Abs(a[f.index(i, k)]) > math.Abs(a[f.index(p, k)]) {
+				p = i
+			}
+		}
+		f.piv[k] = p
+		if p != k {
+			for c := k; c <= cmax; c++ {
+				a[f.index(p, c)], a[f.index(k, c)] = a[f.index(k, c)], a[f.index(p, c)]
+			}
+			f.sign = -f.sign
+		}
+
+		pivot := a[f.index(k, k)]
+		if pivot == 0. {
+			continue
+		}
+		for i := k + 1; i <= last; i++ {
+			mult := a[f.index(i, k)] / pivot
+			f.l[k*kl+i-k-1] = mult
+			a[f.index(i, k)] = 0.
+			for c := k + 1; c <= cmax; c++ {
+				a[f.index(i, c)] -= mult * a[f.index(k, c)]
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// index returns the position of the element [r][c] in the working
+// storage, where row r holds the columns r-kl to r+kl+ku.
+func (f *BandedLU) index(r, c int) int {
+	return r*(2*f.kl+f.ku+1) + c - r + f.kl
+}
+
+// IsSingular returns true if one of the pivots of U is zero, or
+// too small compared to the elements of the original matrix.
+func (f *BandedLU) IsSingular() bool {
+	for k := 0; k < f.n; k++ {
+		if math.Abs(f.u[f.index(k, k)]) <= f.tol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Det returns the determinant of the decomposed matrix.
+func (f *BandedLU) Det() float64 {
+	det := f.sign
+	for k := 0; k < f.n; k++ {
+		det *= f.u[f.index(k, k)]
+	}
+
+	return det
+}
+
+// Solve returns the solution x of the system A*x = b. An error
+// is generated if the length of b does not match the order of A,
+// and ErrSingular is returned if A is singular.
+func (f *BandedLU) Solve(b []float64) ([]float64, error) {
+	if len(b) != f.n {
+		return nil, fmt.Errorf("b has %d elements, expected %d", len(b), f.n)
+	}
+	if f.IsSingular() {
+		return nil, ErrSingular
+	}
+
+	x := make([]float64, f.n)
+	copy(x, b)
+	f.solveInPlace(x)
+
+	return x, nil
+}
+
+// SolveMatrix returns the solution X of the system A*X = B,
+// solving A for each column of B. An error is generated if the
+// number of rows of B does not match the order of A, and
+// ErrSingular is returned if A is singular.
+func (f *BandedLU) SolveMatrix(b Matrix) (Matrix, error) {
+	if b.rows != f.n {
+		return Matrix{}, fmt.Errorf("B has %d rows, expected %d", b.rows, f.n)
+	}
+	if f.IsSingular() {
+		return Matrix{}, ErrSingular
+	}
+
+	x := StartZerosMatrix(b.rows, b.cols)
+	col := make([]float64, f.n)
+	for c := 0; c < b.cols; c++ {
+		for r := 0; r < f.n; r++ {
+			col[r] = b.at(r, c)
+		}
+		f.solveInPlace(col)
+		for r := 0; r < f.n; r++ {
+			x.elems[r*x.stride+c] = col[r]
+		}
+	}
+
+	return x, nil
+}
+
+// solveInPlace overwrites the right-hand side x with the solution
+// of A*x = x, applying the interchanges and multipliers of each
+// step and then back substituting with U.
+func (f *BandedLU) solveInPlace(x []float64) {
+	n, kl := f.n, f.kl
+	for k := 0; k < n; k++ {
+		if p := f.piv[k]; p != k {
+			x[p], x[k] = x[k], x[p]
+		}
+		for i := k + 1; i <= min(n-1, k+kl); i++ {
+			x[i] -= f.l[k*kl+i-k-1] * x[k]
+		}
+	}
+	for r := n - 1; r >= 0; r-- {
+		for c := r + 1; c <= min(n-1, r+f.kl+f.ku); c++ {
+			x[r] -= f.u[f.index(r, c)] * x[c]
+		}
+		x[r] /= f.u[f.index(r, r)]
+	}
+}
@@ -0,0 +1,149 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSolveTridiagonal(t *testing.T) {
+	// Finite-difference heat equation: -u[i-1] + 2u[i] - u[i+1].
+	n := 50
+	sub := make([]float64, n-1)
+	sup := make([]float64, n-1)
+	diag := make([]float64, n)
+	b := make([]float64, n)
+	for i := range diag {
+		diag[i] = 2.
+		b[i] = float64(i%7) - 3.
+		if i < n-1 {
+			sub[i], sup[i] = -1., -1.
+		}
+	}
+
+	x, err := SolveTridiagonal(sub, diag, sup, b)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	a, _ := StartTridiagonal(sub, diag, sup)
+	ax, _ := ToDense(a).MulVec(x)
+	for i := range b {
+		if math.Abs(ax[i]-b[i]) > 1e-9 {
+			t.Fatalf("incorrect result: expected (A*x)[%d] = %v, got %v.", i, b[i], ax[i])
+		}
+	}
+
+	_, err = SolveTridiagonal([]float64{5}, []float64{1, 1}, []float64{0}, []float64{1, 1})
+	var nd NotDiagonallyDominantError
+	if !errors.As(err, &nd) || nd.Row != 1 {
+		t.Errorf("incorrect result: expected NotDiagonallyDominantError at row 1, got %v.", err)
+	}
+
+	_, err = SolveTridiagonal([]float64{1}, []float64{1, 1}, []float64{1}, []float64{1})
+	if !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+}
+
+func TestBandedLU(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+	n, kl, ku := 30, 2, 3
+	a, _ := StartBanded(n, n, kl, ku)
+	for r := 0; r < n; r++ {
+		for c := max(0, r-kl); c <= min(n-1, r+ku); c++ {
+			a.Set(r, c, rnd.Float64()*2-1)
+		}
+	}
+	dense := ToDense(a)
+
+	f, err := a.LU()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+
+	ans, _ := dense.Det()
+	if det := f.Det(); math.Abs(det-ans) > 1e-9*math.Abs(ans) {
+		t.Errorf("incorrect result: expected det %v, got %v.", ans, det)
+	}
+
+	b := make([]float64, n)
+	for i := range b {
+		b[i] = float64(i)
+	}
+	x, err := f.Solve(b)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ax, _ := dense.MulVec(x)
+	for i := range b {
+		if math.Abs(ax[i]-b[i]) > 1e-9 {
+			t.Fatalf("incorrect result: expected (A*x)[%d] = %v, got %v.", i, b[i], ax[i])
+		}
+	}
+
+	inv, err := f.SolveMatrix(StartIdentityMatrix(n))
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	id, _ := dense.Product(inv)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			want := 0.
+			if r == c {
+				want = 1.
+			}
+			if math.Abs(id.at(r, c)-want) > 1e-9 {
+				t.Fatalf("incorrect result: expected A*A⁻¹ = I, got %v at [%d][%d].", id.at(r, c), r, c)
+			}
+		}
+	}
+}
+
+func TestBandedLUPivoting(t *testing.T) {
+	// Zero diagonal: the Thomas algorithm fails, the banded LU pivots.
+	sub := []float64{1, 1}
+	diag := []float64{0, 0, 1}
+	sup := []float64{1, 1}
+	a, _ := StartTridiagonal(sub, diag, sup)
+
+	if _, err := SolveTridiagonal(sub, diag, sup, []float64{1, 2, 3}); err == nil {
+		t.Error("incorrect result: expected error, matrix is not diagonally dominant.")
+	}
+
+	f, _ := a.LU()
+	x, err := f.Solve([]float64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans := []float64{0, 1, 2}
+	for i := range ans {
+		if math.Abs(x[i]-ans[i]) > 1e-12 {
+			t.Errorf("incorrect result: expected %v, got %v.", ans, x)
+			break
+		}
+	}
+	if det, _ := a.Det(); math.Abs(det+1) > 1e-12 {
+		t.Errorf("incorrect result: expected det -1, got %v.", det)
+	}
+
+	s, _ := StartTridiagonal([]float64{1}, []float64{1, 1}, []float64{1})
+	fs, _ := s.LU()
+	if _, err := fs.Solve([]float64{1, 1}); !errors.Is(err, ErrSingular) {
+		t.Errorf("incorrect result: expected ErrSingular, got %v.", err)
+	}
+}
+
+func TestToBanded(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 1, 2, 0, 3, 4, 5, 0, 6, 7)
+	b, err := m.ToBanded(1, 1)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	if !m.IsEqual(ToDense(b)) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", m, ToDense(b))
+	}
+	if _, err := m.ToBanded(0, 1); err == nil {
+		t.Error("incorrect result: expected error, element out of the band.")
+	}
+}
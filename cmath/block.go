@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+)
+
+// HStack returns the matrices ms placed side by side, [m0 m1 ...].
+// An error wrapping ErrDimension is generated if they do not have
+// the same number of rows.
+func HStack(ms ...Matrix) (Matrix, error) {
+	return Block([][]Matrix{ms})
+}
+
+// VStack returns the matrices ms placed one over the other, with m0
+// at the top. An error wrapping ErrDimension is generated if they
+// do not have the same number of columns.
+func VStack(ms ...Matrix) (Matrix, error) {
+	grid := make([][]Matrix, len(ms))
+	for k, m := range ms {
+		grid[k] = []Matrix{m}
+	}
+
+	return Block(grid)
+}
+
+/*
+Block assembles a matrix from a grid of blocks, where grid[i][j] is
+the block in the block row i and block column j, such as
+
+	Block([][]Matrix{{A, B}, {C, D}})
+
+for the matrix [A B; C D]. All the blocks of a block row must have
+the same number of rows, and all the blocks of a block column the
+same number of columns, otherwise an error wrapping ErrDimension is
+generated.
+*/
+func Block(grid [][]Matrix) (Matrix, error) {
+	if len(grid) == 0 {
+		return StartZerosMatrix(0, 0), nil
+	}
+
+	ncols := len(grid[0])
+	widths := make([]int, ncols)
+	for j, b := range grid[0] {
+		widths[j] = b.cols
+	}
+
+	rows, cols := 0, 0
+	for _, w := range widths {
+		cols += w
+	}
+	for i, brow := range grid {
+		if len(brow) != ncols {
+			return Matrix{}, fmt.Errorf("block row %d has %d blocks, expected %d: %w", i, len(brow), ncols, ErrDimension)
+		}
+		for j, b := range brow {
+			if b.rows != brow[0].rows {
+				return Matrix{}, fmt.Errorf("block [%d][%d] has %d rows, expected %d: %w", i, j, b.rows, brow[0].rows, ErrDimension)
+			}
+			if b.cols != widths[j] {
+				return Matrix{}, fmt.Errorf("block [%d][%d] has %d columns, expected %d: %w", i, j, b.cols, widths[j], ErrDimension)
+			}
+		}
+		if ncols > 0 {
+			rows += brow[0].rows
+		}
+	}
+
+	result := StartZerosMatrix(rows, cols)
+	r0 := 0
+	for _, brow := range grid {
+		c0 := 0
+		for _, b := range brow {
+			for r := 0; r < b.rows; r++ {
+				copy(result.row(r0 + r)[c0:], b.row(r))
+			}
+			c0 += b.cols
+		}
+		if ncols > 0 {
+			r0 += brow[0].rows
+		}
+	}
+
+	return result, nil
+}
+
+// Submatrix returns a copy of the rows r0 to r1-1 and columns c0 to
+// c1-1 of the current matrix, m. Unlike Slice, the result does not
+// share the elements of m. An error is generated if the limits are
+// outside the matrix.
+func (m Matrix) Submatrix(r0, r1, c0, c1 int) (Matrix, error) {
+	view, err := m.Slice(r0, r1, c0, c1)
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	result := StartZerosMatrix(view.rows, view.cols)
+	for r := 0; r < view.rows; r++ {
+		copy(result.row(r), view.row(r))
+	}
+
+	return result, nil
+}
+
+// Kronecker returns the Kronecker product of the current matrix, m,
+// by the matrix other, the block matrix with the blocks
+// m[i][j]*other.
+func (m Matrix) Kronecker(other Matrix) Matrix {
+	result := StartZerosMatrix(m.rows*other.rows, m.cols*other.cols)
+	for i := 0; i < m.rows; i++ {
+		for j, a := range m.row(i) {
+			for r := 0; r < other.rows; r++ {
+				dst := result.row(i*other.rows + r)[j*other.cols:]
+				for c, b := range other.row(r) {
+					dst[c] = a * b
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// Hadamard returns the element-wise product of the current matrix,
+// m, by the matrix other. An error wrapping ErrDimension is
+// generated if the matrices have different sizes.
+func (m Matrix) Hadamard(other Matrix) (Matrix, error) {
+	if m.rows != other.rows || m.cols != other.cols {
+		return Matrix{}, fmt.Errorf("it is not possible to multiply element-wise matrices of different sizes: %w", ErrDimension)
+	}
+
+	result := StartZerosMatrix(m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		drow, arow, brow := result.row(r), m.row(r), other.row(r)
+		for c := range drow {
+			drow[c] = arow[c] * brow[c]
+		}
+	}
+
+	return result, nil
+}
+
+// HadamardDiv returns the element-wise division of the current
+// matrix, m, by the matrix other. Divisions by zero follow the
+// floating-point rules, giving Inf or NaN elements. An error
+// wrapping ErrDimension is generated if the matrices have
+// different sizes.
+func (m Matrix) HadamardDiv(other Matrix) (Matrix, error) {
+	if m.rows != other.rows || m.cols != other.cols {
+		return Matrix{}, fmt.Errorf("it is not possible to divide element-wise matrices of different sizes: %w", ErrDimension)
+	}
+
+	result := StartZerosMatrix(m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		drow, arow, brow := result.row(r), m.row(r), other.row(r)
+		for c := range drow {
+			drow[c] = arow[c] / brow[c]
+		}
+	}
+
+	return result, nil
+}
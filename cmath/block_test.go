@@ -0,0 +1,118 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	a := must(StartMatrix(2, 2, 1, 2, 3, 4))
+	b := must(StartMatrix(2, 1, 5, 6))
+	c := must(StartMatrix(1, 2, 7, 8))
+
+	h, err := HStack(a, b)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans := must(StartMatrix(2, 3, 1, 2, 5, 3, 4, 6))
+	if !h.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, h)
+	}
+
+	v, err := VStack(a, c)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans = must(StartMatrix(3, 2, 1, 2, 3, 4, 7, 8))
+	if !v.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, v)
+	}
+
+	if _, err := HStack(a, c); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+	if _, err := VStack(a, b); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+}
+
+func TestBlock(t *testing.T) {
+	a := must(StartMatrix(2, 2, 1, 2, 3, 4))
+	b := must(StartMatrix(2, 1, 5, 6))
+	c := must(StartMatrix(1, 2, 7, 8))
+	d := must(StartMatrix(1, 1, 9))
+
+	m, err := Block([][]Matrix{{a, b}, {c, d}})
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans := must(StartMatrix(3, 3, 1, 2, 5, 3, 4, 6, 7, 8, 9))
+	if !m.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, m)
+	}
+
+	bad := [][][]Matrix{
+		{{a, b}, {d, c}},
+		{{a, b}, {c}},
+		{{a, c}, {c, d}},
+	}
+	for _, grid := range bad {
+		if _, err := Block(grid); !errors.Is(err, ErrDimension) {
+			t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+		}
+	}
+}
+
+func TestSubmatrix(t *testing.T) {
+	m := must(StartMatrix(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9))
+	s, err := m.Submatrix(1, 3, 0, 2)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans := must(StartMatrix(2, 2, 4, 5, 7, 8))
+	if !s.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, s)
+	}
+	s.SetElement(0, 0, 0)
+	if m.at(1, 0) != 4 {
+		t.Error("incorrect result: Submatrix must not share the elements.")
+	}
+	if _, err := m.Submatrix(0, 4, 0, 1); err == nil {
+		t.Error("incorrect result: expected error, rows out of range.")
+	}
+}
+
+func TestKronecker(t *testing.T) {
+	a := must(StartMatrix(2, 2, 1, 2, 3, 4))
+	b := must(StartMatrix(1, 2, 0, 5))
+	k := a.Kronecker(b)
+	ans := must(StartMatrix(2, 4, 0, 5, 0, 10, 0, 15, 0, 20))
+	if !k.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, k)
+	}
+}
+
+func TestHadamard(t *testing.T) {
+	a := must(StartMatrix(2, 2, 1, 2, 3, 4))
+	b := must(StartMatrix(2, 2, 2, 0, -1, 8))
+
+	h, _ := a.Hadamard(b)
+	ans := must(StartMatrix(2, 2, 2, 0, -3, 32))
+	if !h.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, h)
+	}
+
+	d, _ := a.HadamardDiv(b)
+	if d.at(0, 0) != 0.5 || !math.IsInf(d.at(0, 1), 1) || d.at(1, 1) != 0.5 {
+		t.Errorf("incorrect result: expected [0.5 +Inf; -3 0.5], got\n%v.", d)
+	}
+
+	c := StartZerosMatrix(2, 3)
+	if _, err := a.Hadamard(c); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+	if _, err := a.HadamardDiv(c); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+}
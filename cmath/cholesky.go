@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Cholesky holds the Cholesky factorization A = L*Lᵀ of a symmetric
+positive-definite matrix A, where L is a lower triangular matrix
+with positive diagonal elements.
+
+For these matrices, such as covariance and stiffness matrices,
+the factorization takes about half the work of the LU
+decomposition and needs no pivoting.
+*/
+type Cholesky struct {
+	l [][]float64
+	n int
+}
+
+// Cholesky returns the Cholesky factorization of the current
+// matrix, m. A NotSquareError is returned if m is not square,
+// ErrNotSymmetric if m is not symmetric and a
+// NotPositiveDefiniteError, with the failing pivot, if m is not
+// positive definite.
+func (m Matrix) Cholesky() (*Cholesky, error) {
+	if m.rows != m.cols {
+		return nil, NotSquareError{m.rows, m.cols}
+	}
+	if !m.isSymmetric() {
+		return nil, ErrNotSymmetric
+	}
+
+	n := m.rows
+	l := make([][]float64, n)
+	for j := 0; j < n; j++ {
+		l[j] = make([]float64, n)
+
+		// Elements of row j left of the diagonal.
+		for k := 0; k < j; k++ {
+			s := m.at(j, k)
+			for i := 0; i < k; i++ {
+				s -= l[j][i] * l[k][i]
+			}
+			l[j][k] = s / l[k][k]
+		}
+
+		d := m.at(j, j)
+		for k := 0; k < j; k++ {
+			d -= l[j][k] * l[j][k]
+		}
+		if d <= 0. || math.IsNaN(d) {
+			return nil, NotPositiveDefiniteError{j, d}
+		}
+		l[j][j] = math.Sqrt(d)
+	}
+
+	return &Cholesky{l: l, n: n}, nil
+}
+
+// L returns the lower triangular factor.
+func (f *Cholesky) L() Matrix {
+	l := StartZerosMatrix(f.n, f.n)
+	for r := 0; r < f.n; r++ {
+		copy(l.row(r)[:r+1], f.l[r][:r+1])
+	}
+
+	return l
+}
+
+// Det returns the determinant of the factored matrix, the square
+// of the product of the diagonal elements of L.
+func (f *Cholesky) Det() float64 {
+	det := 1.
+	for k := 0; k < f.n; k++ {
+		det *= f.l[k][k]
+	}
+
+	return det * det
+}
+
+// LogDet returns the natural logarithm of the determinant of the
+// factored matrix. It avoids the overflow or underflow of Det for
+// large matrices.
+func (f *Cholesky) LogDet() float64 {
+	s := 0.
+	for k := 0; k < f.n; k++ {
+		s += math.Log(f.l[k][k])
+	}
+
+	return 2. * s
+}
+
+// Solve returns the solution x of the system A*x = b. An error is
+// generated if the length of b does not match the order of A.
+func (f *Cholesky) Solve(b []float64) ([]float64, error) {
+	if len(b) != f.n {
+		return nil, fmt.Errorf("b has %d elements, expected %d", len(b), f.n)
+	}
+
+	x := make([]float64, f.n)
+	copy(x, b)
+	f.solveInPlace(x)
+
+	return x, nil
+}
+
+// SolveMatrix returns the solution X of the system A*X = B. An
+// error is generated if the number of rows of B does not match
+// the order of A.
+func (f *Cholesky) SolveMatrix(b Matrix) (Matrix, error) {
+	if b.rows != f.n {
+		return Matrix{}, fmt.Errorf("B has %d rows, expected %d", b.rows, f.n)
+	}
+
+	x := StartZerosMatrix(b.rows, b.cols)
+	col := make([]float64, f.n)
+	for c := 0; c < b.cols; c++ {
+		for r := 0; r < f.n; r++ {
+			col[r] = b.at(r, c)
+		}
+		f.solveInPlace(col)
+		for r := 0; r < f.n; r++ {
+			x.elems[r*x.stride+c] = col[r]
+		}
+	}
+
+	return x, nil
+}
+
+// Inverse returns the inverse of the factored matrix.
+func (f *Cholesky) Inverse() Matrix {
+	inv, _ := f.SolveMatrix(StartIdentityMatrix(f.n))
+
+	return inv
+}
+
+// solveInPlace overwrites x with the solution of L*Lᵀ*x = x.
+func (f *Cholesky) solveInPlace(x []float64) {
+	l := f.l
+	for r := 0; r < f.n; r++ {
+		for c := 0; c < r; c++ {
+			x[r] -= l[r][c] * x[c]
+		}
+		x[r] /= l[r][r]
+	}
+	for r := f.n - 1; r >= 0; r-- {
+		for c := r + 1; c < f.n; c++ {
+			x[r] -= l[c][r] * x[c]
+		}
+		x[r] /= l[r][r]
+	}
+}
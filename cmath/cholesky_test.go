@@ -0,0 +1,68 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 4, 12, -16, 12, 37, -43, -16, -43, 98)
+	ch, err := m.Cholesky()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+
+	ans, _ := StartMatrix(3, 3, 2, 0, 0, 6, 1, 0, -8, 5, 3)
+	if l := ch.L(); !ans.IsEqual(l) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, l)
+	}
+
+	if d := ch.Det(); math.Abs(d-36.) > 1e-10 {
+		t.Errorf("incorrect result: expected d = %1.2f, got %1.2f.", 36., d)
+	}
+	if ld := ch.LogDet(); math.Abs(ld-math.Log(36.)) > 1e-12 {
+		t.Errorf("incorrect result: expected log(d) = %v, got %v.", math.Log(36.), ld)
+	}
+
+	x, err := ch.Solve([]float64{-20, -43, 192})
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	xans := []float64{1, 2, 3}
+	for i := range xans {
+		if math.Abs(x[i]-xans[i]) > 1e-10 {
+			t.Fatalf("incorrect result: expected %v, got %v.", xans, x)
+		}
+	}
+	if _, err := ch.Solve([]float64{1, 2}); err == nil {
+		t.Error("incorrect result: expected error, b has wrong size.")
+	}
+
+	inv := ch.Inverse()
+	luInv, _ := m.Inverse()
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			if math.Abs(inv.at(r, c)-luInv.at(r, c)) > 1e-10 {
+				t.Fatalf("incorrect result: expected \n%v, got\n%v.", luInv, inv)
+			}
+		}
+	}
+}
+
+func TestCholeskyErrors(t *testing.T) {
+	m, _ := StartMatrix(2, 2, 1, 2, 3, 4)
+	if _, err := m.Cholesky(); !errors.Is(err, ErrNotSymmetric) {
+		t.Errorf("incorrect result: expected ErrNotSymmetric, got %v.", err)
+	}
+
+	m, _ = StartMatrix(3, 3, 1, 2, 0, 2, 1, 0, 0, 0, 1)
+	_, err := m.Cholesky()
+	var npdErr NotPositiveDefiniteError
+	if !errors.As(err, &npdErr) {
+		t.Fatalf("incorrect result: expected NotPositiveDefiniteError, got %v.", err)
+	}
+	if npdErr.Pivot != 1 || npdErr.Value != -3. {
+		t.Errorf("incorrect result: expected pivot 1 = -3, got pivot %d = %v.", npdErr.Pivot, npdErr.Value)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// CLU holds the LU decomposition with partial pivoting of a square
+// complex matrix A, so that P*A = L*U. It is the complex version
+// of LU, with the pivots chosen by their magnitude.
+type CLU struct {
+	lu   [][]complex128
+	piv  []int
+	sign float64
+	tol  float64
+	n    int
+}
+
+// LU returns the LU decomposition with partial pivoting of the
+// current complex matrix, m. A NotSquareError is returned if m is
+// not a square matrix.
+func (m CMatrix) LU() (*CLU, error) {
+	if m.rows != m.cols {
+		return nil, NotSquareError{m.rows, m.cols}
+	}
+
+	n := m.rows
+	f := &CLU{
+		lu:   make([][]complex128, n),
+		piv:  make([]int, n),
+		sign: 1.,
+		n:    n,
+	}
+
+	amax := 0.
+	for r := 0; r < n; r++ {
+		f.piv[r] = r
+		f.lu[r] = make([]complex128, n)
+		copy(f.lu[r], m.elems[r*m.stride:r*m.stride+n])
+		for _, v := range f.lu[r] {
+			amax = math.Max(amax, cmplx.Abs(v))
+		}
+	}
+	f.tol = float64(n) * amax * epsilon
+
+	a := f.lu
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if cmplx.Abs(a[i][k]) > cmplx.Abs(a[p][k]) {
+				p = i
+			}
+		}
+		if p != k {
+			a[p], a[k] = a[k], a[p]
+			f.piv[p], f.piv[k] = f.piv[k], f.piv[p]
+			f.sign = -f.sign
+		}
+
+		if a[k][k] == 0 {
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			a[i][k] /= a[k][k]
+			for j := k + 1; j < n; j++ {
+				a[i][j] -= a[i][k] * a[k][j]
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// IsSingular returns true if one of the pivots of U is zero, or
+// too small compared to the elements of the original matrix.
+func (f *CLU) IsSingular() bool {
+	for k := 0; k < f.n; k++ {
+		if cmplx.Abs(f.lu[k][k]) <= f.tol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Det returns the determinant of the decomposed matrix.
+func (f *CLU) Det() complex128 {
+	det := complex(f.sign, 0)
+	for k := 0; k < f.n; k++ {
+		det *= f.lu[k][k]
+	}
+
+	return det
+}
+
+// Solve returns the solution x of the system A*x = b. An error
+// is generated if the length of b does not match the order of A,
+// and ErrSingular is returned if A is singular.
+func (f *CLU) Solve(b []complex128) ([]complex128, error) {
+	if len(b) != f.n {
+		return nil, fmt.Errorf("b has %d elements, expected %d", len(b), f.n)
+	}
+	if f.IsSingular() {
+		return nil, ErrSingular
+	}
+
+	x := make([]complex128, f.n)
+	for r := 0; r < f.n; r++ {
+		x[r] = b[f.piv[r]]
+	}
+	f.solveInPlace(x)
+
+	return x, nil
+}
+
+// SolveMatrix returns the solution X of the system A*X = B,
+// solving A for each column of B. An error is generated if the
+// number of rows of B does not match the order of A, and
+// ErrSingular is returned if A is singular.
+func (f *CLU) SolveMatrix(b CMatrix) (CMatrix, error) {
+	if b.rows != f.n {
+		return CMatrix{}, fmt.Errorf("B has %d rows, expected %d", b.rows, f.n)
+	}
+	if f.IsSingular() {
+		return CMatrix{}, ErrSingular
+	}
+
+	x := StartZerosCMatrix(b.rows, b.cols)
+	col := make([]complex128, f.n)
+	for c := 0; c < b.cols; c++ {
+		for r := 0; r < f.n; r++ {
+			col[r] = b.at(f.piv[r], c)
+		}
+		f.solveInPlace(col)
+		for r := 0; r < f.n; r++ {
+			x.elems[r*x.stride+c] = col[r]
+		}
+	}
+
+	return x, nil
+}
+
+// Inverse returns the inverse of the decomposed matrix. ErrSingular
+// is returned if the matrix is singular.
+func (f *CLU) Inverse() (CMatrix, error) {
+	id := StartZerosCMatrix(f.n, f.n)
+	for k := 0; k < f.n; k++ {
+		id.elems[k*id.stride+k] = 1
+	}
+
+	return f.SolveMatrix(id)
+}
+
+// solveInPlace overwrites the permuted right-hand side x with
+// the solution of L*U*x = x, by forward and back substitution.
+func (f *CLU) solveInPlace(x []complex128) {
+	a := f.lu
+	for r := 1; r < f.n; r++ {
+		for c := 0; c < r; c++ {
+			x[r] -= a[r][c] * x[c]
+		}
+	}
+	for r := f.n - 1; r >= 0; r-- {
+		for c := r + 1; c < f.n; c++ {
+			x[r] -= a[r][c] * x[c]
+		}
+		x[r] /= a[r][r]
+	}
+}
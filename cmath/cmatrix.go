@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"math/cmplx"
+)
+
+/*
+CMatrix declares a matrix of complex128 elements, with the same
+operations of Matrix, plus the conjugate transpose and the
+Hermitian test. It is used where real matrices are not enough,
+such as the phasor equations of AC circuits.
+
+Array elements can be initialized by the StartCMatrix and
+StartZerosCMatrix methods, or converted from a Matrix by
+ToCMatrix. CMatrix is defined as the complex128 MatrixOf, so the
+operations that do not depend on the element type are shared with
+it.
+*/
+type CMatrix MatrixOf[complex128]
+
+// StartCMatrix starts a complex matrix with r rows and c columns
+// with the elements passed by list e. An error is generated if the
+// number of elements does not match the product r*c, or if r or c
+// is negative.
+func StartCMatrix(r int, c int, e ...complex128) (CMatrix, error) {
+	m, err := StartMatrixOf(r, c, e...)
+
+	return CMatrix(m), err
+}
+
+// StartZerosCMatrix starts a complex matrix with zero elements
+// with r rows and c columns. r and c must not be negative,
+// otherwise it panics.
+func StartZerosCMatrix(r, c int) CMatrix {
+	return CMatrix(StartZerosMatrixOf[complex128](r, c))
+}
+
+// ToCMatrix returns the complex matrix with the real elements of
+// the matrix m.
+func ToCMatrix(m Matrix) CMatrix {
+	result := StartZerosCMatrix(m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.row(r) {
+			result.elems[r*result.stride+c] = complex(v, 0)
+		}
+	}
+
+	return result
+}
+
+// GetElement returns the matrix elements of the row r and column
+// c. An error is generated if the requested element exceeds the
+// matrix size.
+func (m CMatrix) GetElement(r, c int) (complex128, error) {
+	return MatrixOf[complex128](m).GetElement(r, c)
+}
+
+// SetElement changes the matrix elements of row r and column c
+// with value v. An error is generated if the requested element
+// exceeds the matrix size.
+func (m *CMatrix) SetElement(r, c int, v complex128) error {
+	return (*MatrixOf[complex128])(m).SetElement(r, c, v)
+}
+
+// Dims returns the number of rows and columns of the current
+// matrix, m.
+func (m CMatrix) Dims() (int, int) {
+	return m.rows, m.cols
+}
+
+// at returns the element [r][c] of m, without bounds checking.
+func (m CMatrix) at(r, c int) complex128 {
+	return m.elems[r*m.stride+c]
+}
+
+// Real returns the matrix with the real parts of the elements of
+// the current matrix, m.
+func (m CMatrix) Real() Matrix {
+	result := StartZerosMatrix(m.rows, m.cols)
+	for k, v := range m.elems {
+		result.elems[k] = real(v)
+	}
+
+	return result
+}
+
+// Imag returns the matrix with the imaginary parts of the elements
+// of the current matrix, m.
+func (m CMatrix) Imag() Matrix {
+	result := StartZerosMatrix(m.rows, m.cols)
+	for k, v := range m.elems {
+		result.elems[k] = imag(v)
+	}
+
+	return result
+}
+
+// IsEqual compares the current matrix, m, with the matrix other
+// and returns true if they are equal.
+func (m CMatrix) IsEqual(other CMatrix) bool {
+	return MatrixOf[complex128](m).IsEqual(MatrixOf[complex128](other))
+}
+
+// Add returns the addition of the current matrix, m, by the
+// other matrix. An error is generated if the arrays have
+// different sizes.
+func (m CMatrix) Add(other CMatrix) (CMatrix, error) {
+	result, err := MatrixOf[complex128](m).Add(MatrixOf[complex128](other))
+
+	return CMatrix(result), err
+}
+
+// Sub returns the subtraction of the current matrix, m, by the
+// other matrix. An error is generated if the arrays have different
+// sizes.
+func (m CMatrix) Sub(other CMatrix) (CMatrix, error) {
+	result, err := MatrixOf[complex128](m).Sub(MatrixOf[complex128](other))
+
+	return CMatrix(result), err
+}
+
+// ScalarProduct returns the product of the current matrix, m, by
+// the complex constant s.
+func (m CMatrix) ScalarProduct(s complex128) CMatrix {
+	return CMatrix(MatrixOf[complex128](m).ScalarProduct(s))
+}
+
+// Product returns the product between the current matrix, m, and
+// the matrix other. An error is generated if the number of columns
+// of m is different from the number of rows of other.
+func (m CMatrix) Product(other CMatrix) (CMatrix, error) {
+	result, err := MatrixOf[complex128](m).Product(MatrixOf[complex128](other))
+
+	return CMatrix(result), err
+}
+
+// MulVec returns the product of the current matrix, m, by the
+// column vector x. An error is generated if the length of x is
+// different from the number of columns of m.
+func (m CMatrix) MulVec(x []complex128) ([]complex128, error) {
+	return MatrixOf[complex128](m).MulVec(x)
+}
+
+// Transpose returns the transpose of the current matrix, m,
+// without conjugating its elements.
+func (m CMatrix) Transpose() CMatrix {
+	return CMatrix(MatrixOf[complex128](m).Transpose())
+}
+
+// Conj returns the matrix with the complex conjugates of the
+// elements of the current matrix, m.
+func (m CMatrix) Conj() CMatrix {
+	result := StartZerosCMatrix(m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			result.elems[r*result.stride+c] = cmplx.Conj(m.at(r, c))
+		}
+	}
+
+	return result
+}
+
+// ConjTranspose returns the conjugate transpose (Hermitian adjoint)
+// of the current matrix, m.
+func (m CMatrix) ConjTranspose() CMatrix {
+	result := StartZerosCMatrix(m.cols, m.rows)
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			result.elems[c*result.stride+r] = cmplx.Conj(m.at(r, c))
+		}
+	}
+
+	return result
+}
+
+// IsHermitian returns true if the current matrix, m, is square and
+// equal to its conjugate transpose, with a tolerance tol in the
+// magnitude of the difference between m[r][c] and conj(m[c][r]).
+func (m CMatrix) IsHermitian(tol float64) bool {
+	if m.rows != m.cols {
+		return false
+	}
+
+	for r := 0; r < m.rows; r++ {
+		for c := r; c < m.cols; c++ {
+			if cmplx.Abs(m.at(r, c)-cmplx.Conj(m.at(c, r))) > tol {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Det returns the determinant of a square complex matrix, computed
+// by LU decomposition. A NotSquareError is returned if the matrix
+// is not square.
+func (m CMatrix) Det() (complex128, error) {
+	lu, err := m.LU()
+	if err != nil {
+		return 0, err
+	}
+
+	return lu.Det(), nil
+}
+
+// Inverse returns the inverse of the current matrix, m, computed
+// by LU decomposition. A NotSquareError is returned if m is not
+// square and ErrSingular if m is singular.
+func (m CMatrix) Inverse() (CMatrix, error) {
+	lu, err := m.LU()
+	if err != nil {
+		return CMatrix{}, err
+	}
+
+	return lu.Inverse()
+}
+
+// String creates formatted output for the array and makes the
+// CMatrix part of the types that satisfy the fmt.Stringer
+// interface.
+func (m CMatrix) String() string {
+	return MatrixOf[complex128](m).String()
+}
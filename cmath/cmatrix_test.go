@@ -0,0 +1,126 @@
+package cmath
+
+import (
+	"errors"
+	"math/cmplx"
+	"testing"
+)
+
+func TestCMatrixArithmetic(t *testing.T) {
+	a, _ := StartCMatrix(2, 2, 1+1i, 2, 0, 1i)
+	b, _ := StartCMatrix(2, 2, 1, -1i, 2i, 3)
+
+	sum, _ := a.Add(b)
+	ans, _ := StartCMatrix(2, 2, 2+1i, 2-1i, 2i, 3+1i)
+	if !sum.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, sum)
+	}
+
+	diff, _ := a.Sub(b)
+	ans, _ = StartCMatrix(2, 2, 1i, 2+1i, -2i, -3+1i)
+	if !diff.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, diff)
+	}
+
+	prod, _ := a.Product(b)
+	ans, _ = StartCMatrix(2, 2, 1+5i, 7-1i, -2, 3i)
+	if !prod.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, prod)
+	}
+
+	ans, _ = StartCMatrix(2, 2, -1+1i, 2i, 0, -1)
+	if s := a.ScalarProduct(1i); !s.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, s)
+	}
+
+	c, _ := StartCMatrix(1, 2, 1, 2)
+	if _, err := a.Add(c); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+	if _, err := c.Product(c); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+
+	m, _ := StartMatrix(1, 2, 1, 2)
+	if !ToCMatrix(m).IsEqual(c) || !c.Real().IsEqual(m) {
+		t.Error("incorrect result: expected conversion between Matrix and CMatrix.")
+	}
+}
+
+func TestConjTranspose(t *testing.T) {
+	a, _ := StartCMatrix(2, 3, 1+1i, 2, 3-2i, 4i, 5, 6)
+	ans, _ := StartCMatrix(3, 2, 1-1i, -4i, 2, 5, 3+2i, 6)
+	if h := a.ConjTranspose(); !h.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, h)
+	}
+	ans, _ = StartCMatrix(3, 2, 1+1i, 4i, 2, 5, 3-2i, 6)
+	if tr := a.Transpose(); !tr.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, tr)
+	}
+
+	h, _ := StartCMatrix(2, 2, 2, 1-1i, 1+1i, 3)
+	if !h.IsHermitian(0) {
+		t.Error("incorrect result: expected Hermitian matrix.")
+	}
+	if a.IsHermitian(0) {
+		t.Error("incorrect result: expected non-Hermitian matrix.")
+	}
+	nh, _ := StartCMatrix(2, 2, 2i, 1, 1, 3)
+	if nh.IsHermitian(1e-12) {
+		t.Error("incorrect result: diagonal is not real, expected non-Hermitian matrix.")
+	}
+}
+
+func TestCLU(t *testing.T) {
+	// AC circuit: two meshes with impedances R + jX.
+	z, _ := StartCMatrix(2, 2, 10+5i, -5i, -5i, 8-2i)
+	v := []complex128{10, 0}
+
+	f, err := z.LU()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	x, err := f.Solve(v)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	zx, _ := z.MulVec(x)
+	for k := range v {
+		if cmplx.Abs(zx[k]-v[k]) > 1e-12 {
+			t.Errorf("incorrect result: expected %v, got %v.", v, zx)
+			break
+		}
+	}
+
+	det, _ := z.Det()
+	ans := (10+5i)*(8-2i) - (-5i)*(-5i)
+	if cmplx.Abs(det-ans) > 1e-12 {
+		t.Errorf("incorrect result: expected det %v, got %v.", ans, det)
+	}
+
+	inv, err := z.Inverse()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	id, _ := z.Product(inv)
+	for r := 0; r < 2; r++ {
+		for c := 0; c < 2; c++ {
+			want := complex128(0)
+			if r == c {
+				want = 1
+			}
+			if cmplx.Abs(id.at(r, c)-want) > 1e-12 {
+				t.Fatalf("incorrect result: expected Z*Z⁻¹ = I, got\n%v.", id)
+			}
+		}
+	}
+
+	s, _ := StartCMatrix(2, 2, 1, 1i, 1i, -1)
+	if _, err := s.Inverse(); !errors.Is(err, ErrSingular) {
+		t.Errorf("incorrect result: expected ErrSingular, got %v.", err)
+	}
+	var nse NotSquareError
+	if _, err := StartZerosCMatrix(2, 3).LU(); !errors.As(err, &nse) {
+		t.Errorf("incorrect result: expected NotSquareError, got %v.", err)
+	}
+}
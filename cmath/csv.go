@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*
+CSVOptions configures ReadCSV and WriteCSV.
+
+Comma is the field delimiter, ',' if zero; use '\t' for TSV files.
+When HasHeader is true, ReadCSV takes the first row as the column
+names instead of data. WriteCSV writes Header as the first row, if
+it is not nil.
+
+Cells with the text NaN (in any case) are always read as NaN. When
+EmptyAsNaN is true, empty cells are read as NaN too, and WriteCSV
+writes NaN elements as empty cells, as spreadsheets show missing
+data; otherwise an empty cell is an error. Blank lines are not
+empty cells, see ReadCSV.
+*/
+type CSVOptions struct {
+	Comma      rune
+	HasHeader  bool
+	Header     []string
+	EmptyAsNaN bool
+}
+
+// comma returns the delimiter of the options.
+func (o CSVOptions) comma() rune {
+	if o.Comma == 0 {
+		return ','
+	}
+
+	return o.Comma
+}
+
+// ParseError is returned by ReadCSV for a malformed row. Line is the
+// line of the input, and Column the field of the row, both starting
+// at 1. Column is 0 when the error concerns the whole row. For the
+// quoting errors found by encoding/csv, Column is instead the byte
+// of the line where the error is, starting at 1, as in
+// csv.ParseError.
+type ParseError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+/*
+ReadCSV reads a matrix from r, one row of the matrix per record,
+with the options opts. It returns the matrix and the column names,
+if opts.HasHeader is true. All the rows must have the same number
+of fields; blank lines are skipped and spaces around the numbers
+are ignored. Since a blank line is skipped, it is not read as an
+empty cell, even in single-column input with opts.EmptyAsNaN: write
+NaN, or a quoted empty cell "", for a missing value in one column.
+
+A *ParseError with the line and column of the problem is returned
+for malformed records, rows with a different number of fields and
+cells that are not numbers.
+*/
+func ReadCSV(r io.Reader, opts CSVOptions) (Matrix, []string, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = opts.comma()
+	cr.FieldsPerRecord = -1
+	// The reader would take a white space delimiter, such as the
+	// tab of TSV files, as leading space of the next field.
+	cr.TrimLeadingSpace = !unicode.IsSpace(cr.Comma)
+	cr.ReuseRecord = true
+
+	var header []string
+	var elems []float64
+	rows, cols := 0, -1
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				return Matrix{}, nil, &ParseError{Line: perr.Line, Column: perr.Column, Err: perr.Err}
+			}
+			return Matrix{}, nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		if opts.HasHeader && header == nil {
+			header = make([]string, len(record))
+			for k, name := range record {
+				header[k] = strings.TrimSpace(name)
+			}
+			cols = len(record)
+			continue
+		}
+
+		if cols < 0 {
+			cols = len(record)
+		}
+		if len(record) != cols {
+			return Matrix{}, nil, &ParseError{
+				Line: line,
+				Err:  fmt.Errorf("row has %d fields, expected %d: %w", len(record), cols, ErrDimension),
+			}
+		}
+
+		for k, field := range record {
+			v, err := parseCell(strings.TrimSpace(field), opts.EmptyAsNaN)
+			if err != nil {
+				line, _ := cr.FieldPos(k)
+				return Matrix{}, nil, &ParseError{Line: line, Column: k + 1, Err: err}
+			}
+			elems = append(elems, v)
+		}
+		rows++
+	}
+
+	if rows == 0 {
+		return StartZerosMatrix(0, max(cols, 0)), header, nil
+	}
+	m, err := StartMatrix(rows, cols, elems...)
+
+	return m, header, err
+}
+
+// parseCell returns the number of the text of a cell.
+func parseCell(s string, emptyAsNaN bool) (float64, error) {
+	if s == "" {
+		if emptyAsNaN {
+			return math.NaN(), nil
+		}
+		return 0., errors.New("empty cell")
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		var nerr *strconv.NumError
+		if errors.As(err, &nerr) && nerr.Err == strconv.ErrRange {
+			return 0., fmt.Errorf("%q is out of the float64 range", s)
+		}
+		return 0., fmt.Errorf("%q is not a number", s)
+	}
+
+	return v, nil
+}
+
+// WriteCSV writes the matrix m to w, one row per record, with the
+// options opts. The numbers are written with the shortest text that
+// reads back to the same float64. With opts.EmptyAsNaN, NaN elements
+// are written as empty cells, except in single-column matrices, where
+// they are written as NaN so that the row is not a blank line. An
+// error wrapping ErrDimension is
+// generated if opts.Header is not nil and does not have one name per
+// column of m.
+func WriteCSV(w io.Writer, m Matrix, opts CSVOptions) error {
+	if opts.Header != nil && len(opts.Header) != m.cols {
+		return fmt.Errorf("header has %d names for %d columns: %w", len(opts.Header), m.cols, ErrDimension)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = opts.comma()
+	if opts.Header != nil {
+		if err := cw.Write(opts.Header); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, m.cols)
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.row(r) {
+			// An empty cell alone in a record would be a blank line,
+			// which ReadCSV skips, so it is only used with more
+			// columns.
+			if math.IsNaN(v) && opts.EmptyAsNaN && m.cols > 1 {
+				record[c] = ""
+			} else {
+				record[c] = strconv.FormatFloat(v, 'g', -1, 64)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
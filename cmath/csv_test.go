@@ -0,0 +1,129 @@
+package cmath
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	in := "x, y, z\n1, 2.5, -3\n\n4,5e2,  6\n"
+	m, header, err := ReadCSV(strings.NewReader(in), CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans := must(StartMatrix(2, 3, 1, 2.5, -3, 4, 500, 6))
+	if !m.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, m)
+	}
+	if len(header) != 3 || header[0] != "x" || header[2] != "z" {
+		t.Errorf("incorrect result: expected header [x y z], got %q.", header)
+	}
+
+	m, _, err = ReadCSV(strings.NewReader("1\t\tNaN\n"), CSVOptions{Comma: '\t', EmptyAsNaN: true})
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	if m.at(0, 0) != 1 || !math.IsNaN(m.at(0, 1)) || !math.IsNaN(m.at(0, 2)) {
+		t.Errorf("incorrect result: expected [1 NaN NaN], got\n%v.", m)
+	}
+
+	m, _, err = ReadCSV(strings.NewReader(""), CSVOptions{})
+	if err != nil || m.rows != 0 {
+		t.Errorf("incorrect result: expected empty matrix, got %v and %v.", m, err)
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	tests := []struct {
+		in     string
+		opts   CSVOptions
+		line   int
+		column int
+	}{
+		{"1,2\n3,x\n", CSVOptions{}, 2, 2},
+		{"1,2\n3,4\n5\n", CSVOptions{}, 3, 0},
+		{"a,b\n1,2\n\n3,,4\n", CSVOptions{HasHeader: true}, 4, 0},
+		{"1,2\n3,\n", CSVOptions{}, 2, 2},
+		{"1,2\n3,\"4\n", CSVOptions{}, 2, 6},
+		{"1,2\n3,4\"x\n", CSVOptions{}, 2, 4},
+		{"1;2\n3;1e999\n", CSVOptions{Comma: ';'}, 2, 2},
+	}
+	for _, test := range tests {
+		_, _, err := ReadCSV(strings.NewReader(test.in), test.opts)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("incorrect result: %q expected ParseError, got %v.", test.in, err)
+			continue
+		}
+		if perr.Line != test.line || perr.Column != test.column {
+			t.Errorf("incorrect result: %q expected line %d column %d, got %v.", test.in, test.line, test.column, perr)
+		}
+	}
+
+	_, _, err := ReadCSV(strings.NewReader("1,2\n3\n"), CSVOptions{})
+	if !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	m := must(StartMatrix(2, 2, 0.1, -2, math.NaN(), 1e-20))
+
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, m, CSVOptions{Comma: '\t', Header: []string{"a", "b"}, EmptyAsNaN: true})
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans := "a\tb\n0.1\t-2\n\t1e-20\n"
+	if buf.String() != ans {
+		t.Errorf("incorrect result: expected %q, got %q.", ans, buf.String())
+	}
+
+	back, header, err := ReadCSV(&buf, CSVOptions{Comma: '\t', HasHeader: true, EmptyAsNaN: true})
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	if len(header) != 2 || back.at(0, 0) != 0.1 || !math.IsNaN(back.at(1, 0)) || back.at(1, 1) != 1e-20 {
+		t.Errorf("incorrect result: expected round trip of \n%v, got\n%v.", m, back)
+	}
+
+	buf.Reset()
+	WriteCSV(&buf, m, CSVOptions{})
+	if !strings.Contains(buf.String(), "NaN,1e-20") {
+		t.Errorf("incorrect result: expected NaN written as text, got %q.", buf.String())
+	}
+
+	if err := WriteCSV(&buf, m, CSVOptions{Header: []string{"a"}}); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+}
+
+func TestWriteCSVSingleColumn(t *testing.T) {
+	m := must(StartMatrix(3, 1, 1, math.NaN(), 3))
+	opts := CSVOptions{EmptyAsNaN: true}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, m, opts); err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	if ans := "1\nNaN\n3\n"; buf.String() != ans {
+		t.Errorf("incorrect result: expected %q, got %q.", ans, buf.String())
+	}
+
+	back, _, err := ReadCSV(&buf, opts)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	if back.rows != 3 || back.cols != 1 || back.at(0, 0) != 1 || !math.IsNaN(back.at(1, 0)) || back.at(2, 0) != 3 {
+		t.Errorf("incorrect result: expected round trip of \n%v, got\n%v.", m, back)
+	}
+
+	// A quoted empty cell is a missing value, a blank line is dropped.
+	back, _, _ = ReadCSV(strings.NewReader("1\n\"\"\n\n3\n"), opts)
+	if back.rows != 3 || !math.IsNaN(back.at(1, 0)) {
+		t.Errorf("incorrect result: expected [1 NaN 3], got\n%v.", back)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+)
+
+// The functions in this file store the result of the arithmetic
+// operations in a matrix supplied by the caller, dst, instead of
+// allocating a new one. Reusing dst in a loop avoids allocations.
+
+// AddTo stores a + b in dst. dst may be a or b, but must not share
+// elements with them otherwise, as a view of the same matrix shifted
+// by a row does. An error wrapping ErrDimension is generated if the
+// three matrices do not have the same size.
+func AddTo(dst *Matrix, a, b Matrix) error {
+	if a.rows != b.rows || a.cols != b.cols {
+		return fmt.Errorf("it is not possible to add matrices of different sizes: %w", ErrDimension)
+	}
+	if err := checkDst(dst, a.rows, a.cols); err != nil {
+		return err
+	}
+	if partialOverlap(*dst, a) || partialOverlap(*dst, b) {
+		return fmt.Errorf("the destination of A+B must be A, B or not share elements with them")
+	}
+
+	for r := 0; r < a.rows; r++ {
+		drow, arow, brow := dst.row(r), a.row(r), b.row(r)
+		for c := range drow {
+			drow[c] = arow[c] + brow[c]
+		}
+	}
+
+	return nil
+}
+
+// SubTo stores a - b in dst. dst may be a or b, but must not share
+// elements with them otherwise, as a view of the same matrix shifted
+// by a row does. An error wrapping ErrDimension is generated if the
+// three matrices do not have the same size.
+func SubTo(dst *Matrix, a, b Matrix) error {
+	if a.rows != b.rows || a.cols != b.cols {
+		return fmt.Errorf("it is not possible to subtract matrices of different sizes: %w", ErrDimension)
+	}
+	if err := checkDst(dst, a.rows, a.cols); err != nil {
+		return err
+	}
+	if partialOverlap(*dst, a) || partialOverlap(*dst, b) {
+		return fmt.Errorf("the destination of A-B must be A, B or not share elements with them")
+	}
+
+	for r := 0; r < a.rows; r++ {
+		drow, arow, brow := dst.row(r), a.row(r), b.row(r)
+		for c := range drow {
+			drow[c] = arow[c] - brow[c]
+		}
+	}
+
+	return nil
+}
+
+// RealProductTo stores the product of a by the real constant in
+// dst. dst may be a, but must not share elements with it otherwise.
+// An error wrapping ErrDimension is generated if dst and a do not
+// have the same size.
+func RealProductTo(dst *Matrix, a Matrix, real float64) error {
+	if err := checkDst(dst, a.rows, a.cols); err != nil {
+		return err
+	}
+	if partialOverlap(*dst, a) {
+		return fmt.Errorf("the destination of real*A must be A or not share elements with it")
+	}
+
+	for r := 0; r < a.rows; r++ {
+		drow, arow := dst.row(r), a.row(r)
+		for c := range drow {
+			drow[c] = arow[c] * real
+		}
+	}
+
+	return nil
+}
+
+// ProductTo stores the product a*b in dst, which must be a
+// a.rows x b.cols matrix. Since the elements of a and b are read
+// after dst is written, dst must not share elements with them; a
+// view of the same matrix that does not overlap them, such as
+// another block of columns, is allowed. An
+// error wrapping ErrDimension is generated if the sizes do not
+// match.
+func ProductTo(dst *Matrix, a, b Matrix) error {
+	if a.cols != b.rows {
+		return fmt.Errorf("in A*B the A.columns must be equal to B.rows: %w", ErrDimension)
+	}
+	if err := checkDst(dst, a.rows, b.cols); err != nil {
+		return err
+	}
+	if overlaps(*dst, a) || overlaps(*dst, b) {
+		return fmt.Errorf("the destination of A*B must not share elements with A or B")
+	}
+
+	dst.SetZeros()
+	mulBlocked(*dst, a, b)
+
+	return nil
+}
+
+// MulVecTo stores the product of a by the column vector x in dst.
+// dst must not share memory with x. An error wrapping ErrDimension
+// is generated if the lengths of x or dst do not match a.
+func MulVecTo(dst []float64, a Matrix, x []float64) error {
+	if len(x) != a.cols || len(dst) != a.rows {
+		return fmt.Errorf("A*x with A %dx%d needs x with %d and dst with %d elements: %w", a.rows, a.cols, a.cols, a.rows, ErrDimension)
+	}
+
+	for r := 0; r < a.rows; r++ {
+		sum := 0.
+		for c, v := range a.row(r) {
+			sum += v * x[c]
+		}
+		dst[r] = sum
+	}
+
+	return nil
+}
+
+// MulVecTo stores the product of the current matrix, m, by the
+// column vector x in dst. It is the method form of the function
+// MulVecTo, so that Matrix can be used as a linear operator.
+func (m Matrix) MulVecTo(dst, x []float64) error {
+	return MulVecTo(dst, m, x)
+}
+
+// checkDst returns an error if dst is not a r x c matrix.
+func checkDst(dst *Matrix, r, c int) error {
+	if dst.rows != r || dst.cols != c {
+		return fmt.Errorf("destination is %dx%d, expected %dx%d: %w", dst.rows, dst.cols, r, c, ErrDimension)
+	}
+
+	return nil
+}
+
+// overlaps returns true if the matrices x and y share elements.
+// Views of the same matrix share the backing array, and overlap only
+// if their blocks of rows and columns intersect.
+func overlaps(x, y Matrix) bool {
+	if len(x.elems) == 0 || len(y.elems) == 0 {
+		return false
+	}
+
+	// Slices of the same array, extended to their capacity, end at
+	// the same element.
+	xe, ye := x.elems[:cap(x.elems)], y.elems[:cap(y.elems)]
+	if &xe[len(xe)-1] != &ye[len(ye)-1] {
+		return false
+	}
+	if x.stride != y.stride {
+		return true
+	}
+
+	xr, xc := x.origin()
+	yr, yc := y.origin()
+
+	return xr < yr+y.rows && yr < xr+x.rows && xc < yc+y.cols && yc < xc+x.cols
+}
+
+// partialOverlap returns true if the matrices x and y share
+// elements but are not the same view, so that writing x while
+// reading y element by element would read the changed elements.
+func partialOverlap(x, y Matrix) bool {
+	if !overlaps(x, y) {
+		return false
+	}
+
+	return &x.elems[0] != &y.elems[0] || x.stride != y.stride || x.rows != y.rows || x.cols != y.cols
+}
+
+// origin returns the row and column of the first element of m in
+// the matrix that owns its backing array. The rows are counted from
+// the end of the array, which is enough to compare views of the
+// same matrix, and the columns from its start: the array of a
+// matrix has rows*stride elements, so a position counted from the
+// end has the same column as counted from the start. It relies on
+// views, made by Slice, keeping the capacity up to the end of that
+// array.
+func (m Matrix) origin() (r, c int) {
+	back := -cap(m.elems)
+	c = (back%m.stride + m.stride) % m.stride
+
+	return (back - c) / m.stride, c
+}
@@ -0,0 +1,173 @@
+package cmath
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddSubTo(t *testing.T) {
+	a, _ := StartMatrix(2, 2, 1, 2, 3, 4)
+	b, _ := StartMatrix(2, 2, 5, 6, 7, 8)
+	dst := StartZerosMatrix(2, 2)
+
+	if err := AddTo(&dst, a, b); err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	ans, _ := StartMatrix(2, 2, 6, 8, 10, 12)
+	if !ans.IsEqual(dst) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, dst)
+	}
+
+	if err := SubTo(&dst, a, b); err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	ans, _ = StartMatrix(2, 2, -4, -4, -4, -4)
+	if !ans.IsEqual(dst) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, dst)
+	}
+
+	// In place: a = a + b.
+	AddTo(&a, a, b)
+	ans, _ = StartMatrix(2, 2, 6, 8, 10, 12)
+	if !ans.IsEqual(a) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, a)
+	}
+
+	small := StartZerosMatrix(2, 1)
+	if err := AddTo(&small, a, b); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+	if err := SubTo(&dst, a, small); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+}
+
+func TestRealProductTo(t *testing.T) {
+	a, _ := StartMatrix(2, 2, 1, 2, 3, 4)
+	if err := RealProductTo(&a, a, 2); err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	ans, _ := StartMatrix(2, 2, 2, 4, 6, 8)
+	if !ans.IsEqual(a) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, a)
+	}
+
+	dst := StartZerosMatrix(3, 2)
+	if err := RealProductTo(&dst, a, 2); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+}
+
+func TestProductTo(t *testing.T) {
+	a, _ := StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+	b, _ := StartMatrix(3, 2, 1, 0, 0, 1, 1, 1)
+	dst, _ := StartMatrix(2, 2, 9, 9, 9, 9)
+
+	if err := ProductTo(&dst, a, b); err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	ans, _ := StartMatrix(2, 2, 4, 5, 10, 11)
+	if !ans.IsEqual(dst) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, dst)
+	}
+
+	wrong := StartZerosMatrix(3, 3)
+	if err := ProductTo(&wrong, a, b); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+
+	sq, _ := StartMatrix(2, 2, 1, 2, 3, 4)
+	if err := ProductTo(&sq, sq, sq); err == nil {
+		t.Error("incorrect result: expected error, dst is an operand.")
+	}
+	view, _ := a.Slice(0, 2, 1, 3)
+	if err := ProductTo(&view, sq, sq); err != nil {
+		t.Errorf("incorrect result: expected err is nil, got %v.", err)
+	}
+	if err := ProductTo(&view, a, b); err == nil {
+		t.Error("incorrect result: expected error, dst shares elements with a.")
+	}
+}
+
+func TestProductToDisjointViews(t *testing.T) {
+	m, _ := StartMatrix(3, 4,
+		1, 2, 0, 0,
+		3, 4, 0, 0,
+		5, 6, 7, 8)
+	a, _ := m.Slice(0, 2, 0, 2)
+	dst, _ := m.Slice(0, 2, 2, 4)
+
+	// dst and a are disjoint column blocks of m.
+	if err := ProductTo(&dst, a, a); err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans, _ := StartMatrix(3, 4,
+		1, 2, 7, 10,
+		3, 4, 15, 22,
+		5, 6, 7, 8)
+	if !ans.IsEqual(m) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, m)
+	}
+
+	// The rows below a are also disjoint from it.
+	below, _ := m.Slice(2, 3, 0, 2)
+	row, _ := m.Slice(0, 1, 0, 2)
+	if err := ProductTo(&below, row, a); err != nil {
+		t.Errorf("incorrect result: expected err is nil, got %v.", err)
+	}
+
+	// Blocks that share the element [1][1] overlap.
+	mid, _ := m.Slice(1, 3, 1, 3)
+	if err := ProductTo(&mid, a, a); err == nil {
+		t.Error("incorrect result: expected error, dst overlaps a.")
+	}
+}
+
+func TestDestinationAllocs(t *testing.T) {
+	a, _ := StartMatrix(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	b, _ := StartMatrix(3, 3, 9, 8, 7, 6, 5, 4, 3, 2, 1)
+	dst := StartZerosMatrix(3, 3)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		AddTo(&dst, a, b)
+		SubTo(&dst, dst, b)
+		RealProductTo(&dst, dst, 0.5)
+		ProductTo(&dst, a, b)
+	})
+	if allocs != 0 {
+		t.Errorf("incorrect result: expected 0 allocations, got %v.", allocs)
+	}
+}
+
+func TestAddToPartialOverlap(t *testing.T) {
+	m, _ := StartMatrix(3, 2, 1, 2, 3, 4, 5, 6)
+	top, _ := m.Slice(0, 2, 0, 2)
+	bottom, _ := m.Slice(1, 3, 0, 2)
+
+	// bottom is top shifted by a row.
+	if err := AddTo(&bottom, top, top); err == nil {
+		t.Error("incorrect result: expected error, dst partially overlaps a.")
+	}
+	if err := SubTo(&top, bottom, top); err == nil {
+		t.Error("incorrect result: expected error, dst partially overlaps a.")
+	}
+	if err := RealProductTo(&bottom, top, 2); err == nil {
+		t.Error("incorrect result: expected error, dst partially overlaps a.")
+	}
+	if err := AddTo(&bottom, bottom, top); err == nil {
+		t.Error("incorrect result: expected error, dst partially overlaps b.")
+	}
+	ans, _ := StartMatrix(3, 2, 1, 2, 3, 4, 5, 6)
+	if !ans.IsEqual(m) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, m)
+	}
+
+	// The same view is allowed.
+	if err := AddTo(&top, top, top); err != nil {
+		t.Errorf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans, _ = StartMatrix(3, 2, 2, 4, 6, 8, 5, 6)
+	if !ans.IsEqual(m) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, m)
+	}
+}
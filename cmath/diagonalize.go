@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"math"
+)
+
+// defectiveTol is the smallest pivot accepted in the LU
+// decomposition of the modal matrix, relative to its 1-norm. Below
+// it the eigenvectors are considered linearly dependent and the
+// matrix defective.
+const defectiveTol = 1e-7
+
+// Diagonalization holds the decomposition A = P*D*P⁻¹ of a
+// diagonalizable matrix A, where the columns of the modal matrix
+// P are the eigenvectors of A and D is the diagonal matrix with
+// the corresponding eigenvalues, in ascending order.
+type Diagonalization struct {
+	P    Matrix
+	D    Matrix
+	PInv Matrix
+}
+
+// Diagonalize returns the diagonalization of the current matrix,
+// m, over the real numbers: P and D are real matrices. A matrix
+// with complex eigenvalues is not diagonalized, even if it is
+// diagonalizable over the complex numbers. A NotSquareError is
+// returned if m is not square, ErrComplexEigenvalues if m has
+// complex eigenvalues and ErrDefective if m does not have n
+// linearly independent eigenvectors.
+func (m Matrix) Diagonalize() (Diagonalization, error) {
+	if m.rows != m.cols {
+		return Diagonalization{}, NotSquareError{m.rows, m.cols}
+	}
+
+	n := m.rows
+	if m.isSymmetric() {
+		// The eigenvectors of a symmetric matrix are orthonormal,
+		// so P⁻¹ is the transpose of P.
+		eig, err := m.EigenSym()
+		if err != nil {
+			return Diagonalization{}, err
+		}
+		d := StartZerosMatrix(n, n)
+		for k := 0; k < n; k++ {
+			d.elems[k*d.stride+k] = eig.Values[k]
+		}
+		return Diagonalization{
+			P:    eig.Vectors,
+			D:    d,
+			PInv: eig.Vectors.Transpose(),
+		}, nil
+	}
+
+	eig, err := m.Eigen()
+	if err != nil {
+		return Diagonalization{}, err
+	}
+
+	p := StartZerosMatrix(n, n)
+	d := StartZerosMatrix(n, n)
+	for k := 0; k < n; k++ {
+		if imag(eig.Values[k]) != 0. {
+			return Diagonalization{}, ErrComplexEigenvalues
+		}
+		d.elems[k*d.stride+k] = real(eig.Values[k])
+		for r := 0; r < n; r++ {
+			p.elems[r*p.stride+k] = real(eig.Vectors[k][r])
+		}
+	}
+
+	lu, err := p.LU()
+	if err != nil {
+		return Diagonalization{}, err
+	}
+	tol := defectiveTol * p.Norm1()
+	for k := 0; k < n; k++ {
+		if math.Abs(lu.lu[k][k]) < tol {
+			return Diagonalization{}, ErrDefective
+		}
+	}
+	pinv, err := lu.Inverse()
+	if err != nil {
+		return Diagonalization{}, ErrDefective
+	}
+
+	return Diagonalization{P: p, D: d, PInv: pinv}, nil
+}
+
+// Reconstruct returns the product P*D*P⁻¹.
+func (d Diagonalization) Reconstruct() Matrix {
+	pd, _ := d.P.Product(d.D)
+	a, _ := pd.Product(d.PInv)
+
+	return a
+}
+
+// Verify returns true if P*D*P⁻¹ rebuilds the matrix a, with no
+// element differing by more than tol.
+func (d Diagonalization) Verify(a Matrix, tol float64) bool {
+	rec := d.Reconstruct()
+	if rec.rows != a.rows || rec.cols != a.cols {
+		return false
+	}
+
+	for r := 0; r < a.rows; r++ {
+		for c := 0; c < a.cols; c++ {
+			if math.Abs(rec.at(r, c)-a.at(r, c)) > tol {
+				return false
+			}
+		}
+	}
+
+	return true
+}
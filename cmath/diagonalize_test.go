@@ -0,0 +1,71 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDiagonalize(t *testing.T) {
+	tests := []Matrix{
+		must(StartMatrix(2, 2, 4, 1, 2, 3)),
+		must(StartMatrix(3, 3, 2, -1, 0, -1, 2, -1, 0, -1, 2)),
+		must(StartMatrix(3, 3, 4, 1, 2, 0, 3, 1, 0, 0, 5)),
+	}
+
+	for _, m := range tests {
+		diag, err := m.Diagonalize()
+		if err != nil {
+			t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+		}
+		if !diag.Verify(m, 1e-10) {
+			t.Errorf("incorrect result: expected P*D*P⁻¹ = \n%v, got\n%v.", m, diag.Reconstruct())
+		}
+		for r := 0; r < m.rows; r++ {
+			for c := 0; c < m.cols; c++ {
+				if r != c && diag.D.at(r, c) != 0. {
+					t.Errorf("incorrect result: D is not diagonal\n%v.", diag.D)
+				}
+			}
+		}
+	}
+
+	m, _ := StartMatrix(2, 2, 4, 1, 2, 3)
+	diag, _ := m.Diagonalize()
+	if math.Abs(diag.D.at(0, 0)-2.) > 1e-12 || math.Abs(diag.D.at(1, 1)-5.) > 1e-12 {
+		t.Errorf("incorrect result: expected eigenvalues 2 and 5, got\n%v.", diag.D)
+	}
+	other, _ := StartMatrix(2, 2, 4, 1, 2, 4)
+	if diag.Verify(other, 1e-10) {
+		t.Error("incorrect result: expected false, matrices are different.")
+	}
+}
+
+func TestDiagonalizeErrors(t *testing.T) {
+	m, _ := StartMatrix(2, 2, 1, 1, 0, 1)
+	if _, err := m.Diagonalize(); !errors.Is(err, ErrDefective) {
+		t.Errorf("incorrect result: expected ErrDefective, got %v.", err)
+	}
+
+	m, _ = StartMatrix(3, 3, 2, 1, 0, 0, 2, 1, 0, 0, 2)
+	if _, err := m.Diagonalize(); !errors.Is(err, ErrDefective) {
+		t.Errorf("incorrect result: expected ErrDefective, got %v.", err)
+	}
+
+	// The defect check does not depend on the scale of the matrix.
+	for _, scale := range []float64{1e-9, 1e9} {
+		m, _ = StartMatrix(2, 2, scale, scale, 0, scale)
+		if _, err := m.Diagonalize(); !errors.Is(err, ErrDefective) {
+			t.Errorf("incorrect result: scale %g expected ErrDefective, got %v.", scale, err)
+		}
+		m, _ = StartMatrix(2, 2, 4*scale, scale, 2*scale, 3*scale)
+		if diag, err := m.Diagonalize(); err != nil || !diag.Verify(m, 1e-10*scale) {
+			t.Errorf("incorrect result: scale %g expected a diagonalization, got %v.", scale, err)
+		}
+	}
+
+	m, _ = StartMatrix(2, 2, 0, -1, 1, 0)
+	if _, err := m.Diagonalize(); !errors.Is(err, ErrComplexEigenvalues) {
+		t.Errorf("incorrect result: expected ErrComplexEigenvalues, got %v.", err)
+	}
+}
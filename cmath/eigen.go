@@ -0,0 +1,766 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// maxJacobiSweeps limits the number of sweeps over the off-diagonal
+// elements in EigenSym. The cyclic Jacobi method converges
+// quadratically, usually in less than 10 sweeps.
+const maxJacobiSweeps = 100
+
+// SymEigen holds the eigenvalues and eigenvectors of a symmetric
+// matrix. The eigenvalues are real and sorted in ascending order,
+// and the column k of Vectors is the unit eigenvector of Values[k].
+type SymEigen struct {
+	Values  []float64
+	Vectors Matrix
+}
+
+// Eigen holds the eigenvalues and eigenvectors of a general real
+// matrix. The eigenvalues are sorted by their real part and then by
+// their imaginary part, and Vectors[k] is the eigenvector of
+// Values[k], normalized to unit length with its largest component
+// real and positive.
+type Eigen struct {
+	Values  []complex128
+	Vectors [][]complex128
+}
+
+// EigenSym returns the eigenvalues and eigenvectors of the current
+// matrix, m, computed by the cyclic Jacobi method. A NotSquareError
+// is returned if m is not square, ErrNotSymmetric if m is not
+// symmetric and a ConvergenceError if the method does not converge.
+func (m Matrix) EigenSym() (SymEigen, error) {
+	if m.rows != m.cols {
+		return SymEigen{}, NotSquareError{m.rows, m.cols}
+	}
+	if !m.isSymmetric() {
+		return SymEigen{}, ErrNotSymmetric
+	}
+
+	n := m.rows
+	a := m.copyElems()
+	v := StartIdentityMatrix(n).copyElems()
+
+	total := 0.
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			total += a[r][c] * a[r][c]
+		}
+	}
+
+	converged := false
+	for sweep := 0; sweep < maxJacobiSweeps; sweep++ {
+		off := 0.
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += 2 * a[p][q] * a[p][q]
+			}
+		}
+		if off <= epsilon*epsilon*total {
+			converged = true
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := a[p][q]
+				if apq == 0. {
+					continue
+				}
+				// After a few sweeps, elements that are negligible
+				// compared to both diagonal elements are zeroed.
+				g := 100. * math.Abs(apq)
+				if sweep > 3 && math.Abs(a[p][p])+g == math.Abs(a[p][p]) &&
+					math.Abs(a[q][q])+g == math.Abs(a[q][q]) {
+					a[p][q], a[q][p] = 0., 0.
+					continue
+				}
+
+				theta := (a[q][q] - a[p][p]) / (2. * apq)
+				t := 1. / (math.Abs(theta) + math.Sqrt(theta*theta+1.))
+				if theta < 0 {
+					t = -t
+				}
+				cs := 1. / math.Sqrt(t*t+1.)
+				sn := t * cs
+
+				for k := 0; k < n; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p] = cs*akp - sn*akq
+					a[k][q] = sn*akp + cs*akq
+				}
+				for k := 0; k < n; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k] = cs*apk - sn*aqk
+					a[q][k] = sn*apk + cs*aqk
+				}
+				a[p][q], a[q][p] = 0., 0.
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p] = cs*vkp - sn*vkq
+					v[k][q] = sn*vkp + cs*vkq
+				}
+			}
+		}
+	}
+	if !converged {
+		return SymEigen{}, ConvergenceError{"Jacobi eigenvalue method", maxJacobiSweeps}
+	}
+
+	idx := make([]int, n)
+	for k := range idx {
+		idx[k] = k
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return a[idx[i]][idx[i]] < a[idx[j]][idx[j]]
+	})
+
+	result := SymEigen{
+		Values:  make([]float64, n),
+		Vectors: StartZerosMatrix(n, n),
+	}
+	for k, i := range idx {
+		result.Values[k] = a[i][i]
+		// The eigenvectors are already orthonormal, only the sign is
+		// fixed to make the largest component positive.
+		sign := 1.
+		big := 0.
+		for r := 0; r < n; r++ {
+			if math.Abs(v[r][i]) > big {
+				big = math.Abs(v[r][i])
+				sign = math.Copysign(1., v[r][i])
+			}
+		}
+		for r := 0; r < n; r++ {
+			result.Vectors.elems[r*result.Vectors.stride+k] = sign * v[r][i]
+		}
+	}
+
+	return result, nil
+}
+
+// Eigen returns the eigenvalues and eigenvectors of the current
+// matrix, m. Symmetric matrices are solved by EigenSym, the others
+// are reduced to the upper Hessenberg form by orthogonal similarity
+// transformations and then to the real Schur form by the shifted
+// double QR algorithm, which allows complex eigenvalues and
+// eigenvectors. A NotSquareError is returned if m is not square
+// and a ConvergenceError if the QR iteration does not converge.
+func (m Matrix) Eigen() (Eigen, error) {
+	if m.rows != m.cols {
+		return Eigen{}, NotSquareError{m.rows, m.cols}
+	}
+
+	n := m.rows
+	if m.isSymmetric() {
+		sym, err := m.EigenSym()
+		if err != nil {
+			return Eigen{}, err
+		}
+		result := Eigen{
+			Values:  make([]complex128, n),
+			Vectors: make([][]complex128, n),
+		}
+		for k := 0; k < n; k++ {
+			result.Values[k] = complex(sym.Values[k], 0.)
+			result.Vectors[k] = make([]complex128, n)
+			for r := 0; r < n; r++ {
+				result.Vectors[k][r] = complex(sym.Vectors.at(r, k), 0.)
+			}
+		}
+		return result, nil
+	}
+
+	h := m.copyElems()
+	v := StartIdentityMatrix(n).copyElems()
+	orthes(h, v)
+	d, e, err := hqr2(h, v)
+	if err != nil {
+		return Eigen{}, err
+	}
+
+	result := Eigen{
+		Values:  make([]complex128, n),
+		Vectors: make([][]complex128, n),
+	}
+	for k := 0; k < n; k++ {
+		result.Values[k] = complex(d[k], e[k])
+		vec := make([]complex128, n)
+		switch {
+		case e[k] == 0.:
+			for r := 0; r < n; r++ {
+				vec[r] = complex(v[r][k], 0.)
+			}
+		case e[k] > 0.:
+			// A complex pair is stored as the real part, in column k,
+			// and the imaginary part, in column k+1.
+			for r := 0; r < n; r++ {
+				vec[r] = complex(v[r][k], v[r][k+1])
+			}
+		default:
+			for r := 0; r < n; r++ {
+				vec[r] = complex(v[r][k-1], -v[r][k])
+			}
+		}
+		result.Vectors[k] = normalizeComplex(vec)
+	}
+
+	idx := make([]int, n)
+	for k := range idx {
+		idx[k] = k
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		a, b := result.Values[idx[i]], result.Values[idx[j]]
+		if real(a) != real(b) {
+			return real(a) < real(b)
+		}
+		return imag(a) < imag(b)
+	})
+	sorted := Eigen{
+		Values:  make([]complex128, n),
+		Vectors: make([][]complex128, n),
+	}
+	for k, i := range idx {
+		sorted.Values[k] = result.Values[i]
+		sorted.Vectors[k] = result.Vectors[i]
+	}
+
+	return sorted, nil
+}
+
+// normalizeComplex scales v to unit length and rotates it so that
+// its largest component is real and positive.
+func normalizeComplex(v []complex128) []complex128 {
+	norm := 0.
+	k := 0
+	for i, z := range v {
+		a := cmplx.Abs(z)
+		norm += a * a
+		if a > cmplx.Abs(v[k]) {
+			k = i
+		}
+	}
+	if norm == 0. {
+		return v
+	}
+
+	big := cmplx.Abs(v[k])
+	f := cmplx.Conj(v[k]) / complex(big*math.Sqrt(norm), 0.)
+	for i := range v {
+		v[i] *= f
+	}
+	v[k] = complex(big/math.Sqrt(norm), 0.)
+
+	return v
+}
+
+// isSymmetric returns true if m is square and symmetric, apart
+// from rounding errors.
+func (m Matrix) isSymmetric() bool {
+	if m.rows != m.cols {
+		return false
+	}
+
+	amax := 0.
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			amax = math.Max(amax, math.Abs(m.at(r, c)))
+		}
+	}
+	tol := float64(m.rows) * amax * epsilon
+	for r := 0; r < m.rows; r++ {
+		for c := r + 1; c < m.cols; c++ {
+			if math.Abs(m.at(r, c)-m.at(c, r)) > tol {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// orthes reduces h to the upper Hessenberg form by Householder
+// similarity transformations and accumulates them in v, which
+// must start as the identity matrix.
+//
+// This and hqr2 are derived from the Algol procedures orthes,
+// ortran and hqr2 by Martin and Wilkinson (Handbook for Automatic
+// Computation, vol. II - Linear Algebra) and from their Fortran
+// translations in EISPACK.
+func orthes(h, v [][]float64) {
+	n := len(h)
+	low, high := 0, n-1
+	ort := make([]float64, n)
+
+	for m := low + 1; m <= high-1; m++ {
+		scale := 0.
+		for i := m; i <= high; i++ {
+			scale += math.Abs(h[i][m-1])
+		}
+		if scale == 0. {
+			continue
+		}
+
+		// Householder transformation.
+		hh := 0.
+		for i := high; i >= m; i-- {
+			ort[i] = h[i][m-1] / scale
+			hh += ort[i] * ort[i]
+		}
+		g := math.Sqrt(hh)
+		if ort[m] > 0 {
+			g = -g
+		}
+		hh -= ort[m] * g
+		ort[m] -= g
+
+		// Apply Householder similarity transformation
+		// h = (I-u*u'/hh)*h*(I-u*u')/hh)
+		for j := m; j < n; j++ {
+			f := 0.
+			for i := high; i >= m; i-- {
+				f += ort[i] * h[i][j]
+			}
+			f /= hh
+			for i := m; i <= high; i++ {
+				h[i][j] -= f * ort[i]
+			}
+		}
+		for i := 0; i <= high; i++ {
+			f := 0.
+			for j := high; j >= m; j-- {
+				f += ort[j] * h[i][j]
+			}
+			f /= hh
+			for j := m; j <= high; j++ {
+				h[i][j] -= f * ort[j]
+			}
+		}
+		ort[m] *= scale
+		h[m][m-1] = scale * g
+	}
+
+	// Accumulate transformations.
+	for m := high - 1; m >= low+1; m-- {
+		if h[m][m-1] == 0. {
+			continue
+		}
+		for i := m + 1; i <= high; i++ {
+			ort[i] = h[i][m-1]
+		}
+		for j := m; j <= high; j++ {
+			g := 0.
+			for i := m; i <= high; i++ {
+				g += ort[i] * v[i][j]
+			}
+			// Double division avoids possible underflow.
+			g = (g / ort[m]) / h[m][m-1]
+			for i := m; i <= high; i++ {
+				v[i][j] += g * ort[i]
+			}
+		}
+	}
+}
+
+// hqr2 reduces the upper Hessenberg matrix h to the real Schur form
+// by the shifted double QR algorithm and computes the eigenvectors,
+// accumulated in v. It returns the real, d, and imaginary, e, parts
+// of the eigenvalues. Complex pairs are stored in consecutive
+// positions, with the positive imaginary part first.
+func hqr2(h, v [][]float64) (d, e []float64, err error) {
+	nn := len(h)
+	d = make([]float64, nn)
+	e = make([]float64, nn)
+	n := nn - 1
+	low, high := 0, nn-1
+	exshift := 0.
+	var p, q, r, s, z, t, w, x, y float64
+
+	norm := 0.
+	for i := 0; i < nn; i++ {
+		for j := max(i-1, 0); j < nn; j++ {
+			norm += math.Abs(h[i][j])
+		}
+	}
+
+	maxIter := 30 * max(10, nn)
+	iter, total := 0, 0
+	for n >= low {
+		// Look for a single small sub-diagonal element.
+		l := n
+		for l > low {
+			s = math.Abs(h[l-1][l-1]) + math.Abs(h[l][l])
+			if s == 0. {
+				s = norm
+			}
+			if math.Abs(h[l][l-1]) < epsilon*s {
+				break
+			}
+			l--
+		}
+
+		switch {
+		case l == n:
+			// One root found.
+			h[n][n] += exshift
+			d[n] = h[n][n]
+			e[n] = 0.
+			n--
+			iter = 0
+
+		case l == n-1:
+			// Two roots found.
+			w = h[n][n-1] * h[n-1][n]
+			p = (h[n-1][n-1] - h[n][n]) / 2.
+			q = p*p + w
+			z = math.Sqrt(math.Abs(q))
+			h[n][n] += exshift
+			h[n-1][n-1] += exshift
+			x = h[n][n]
+
+			if q >= 0 {
+				// Real pair.
+				if p >= 0 {
+					z = p + z
+				} else {
+					z = p - z
+				}
+				d[n-1] = x + z
+				d[n] = d[n-1]
+				if z != 0. {
+					d[n] = x - w/z
+				}
+				e[n-1] = 0.
+				e[n] = 0.
+				x = h[n][n-1]
+				s = math.Abs(x) + math.Abs(z)
+				p = x / s
+				q = z / s
+				r = math.Sqrt(p*p + q*q)
+				p /= r
+				q /= r
+
+				// Row modification.
+				for j := n - 1; j < nn; j++ {
+					z = h[n-1][j]
+					h[n-1][j] = q*z + p*h[n][j]
+					h[n][j] = q*h[n][j] - p*z
+				}
+				// Column modification.
+				for i := 0; i <= n; i++ {
+					z = h[i][n-1]
+					h[i][n-1] = q*z + p*h[i][n]
+					h[i][n] = q*h[i][n] - p*z
+				}
+				// Accumulate transformations.
+				for i := low; i <= high; i++ {
+					z = v[i][n-1]
+					v[i][n-1] = q*z + p*v[i][n]
+					v[i][n] = q*v[i][n] - p*z
+				}
+			} else {
+				// Complex pair.
+				d[n-1] = x + p
+				d[n] = x + p
+				e[n-1] = z
+				e[n] = -z
+			}
+			n -= 2
+			iter = 0
+
+		default:
+			// No convergence yet, form shift.
+			x = h[n][n]
+			y = 0.
+			w = 0.
+			if l < n {
+				y = h[n-1][n-1]
+				w = h[n][n-1] * h[n-1][n]
+			}
+
+			// Wilkinson's original ad hoc shift.
+			if iter == 10 {
+				exshift += x
+				for i := low; i <= n; i++ {
+					h[i][i] -= x
+				}
+				s = math.Abs(h[n][n-1]) + math.Abs(h[n-1][n-2])
+				x = 0.75 * s
+				y = x
+				w = -0.4375 * s * s
+			}
+
+			// MATLAB's new ad hoc shift.
+			if iter == 30 {
+				s = (y - x) / 2.
+				s = s*s + w
+				if s > 0 {
+					s = math.Sqrt(s)
+					if y < x {
+						s = -s
+					}
+					s = x - w/((y-x)/2.+s)
+					for i := low; i <= n; i++ {
+						h[i][i] -= s
+					}
+					exshift += s
+					x = 0.964
+					y = x
+					w = x
+				}
+			}
+
+			iter++
+			total++
+			if total > maxIter {
+				return nil, nil, ConvergenceError{"QR eigenvalue algorithm", maxIter}
+			}
+
+			// Look for two consecutive small sub-diagonal elements.
+			m := n - 2
+			for m >= l {
+				z = h[m][m]
+				r = x - z
+				s = y - z
+				p = (r*s-w)/h[m+1][m] + h[m][m+1]
+				q = h[m+1][m+1] - z - r - s
+				r = h[m+2][m+1]
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p /= s
+				q /= s
+				r /= s
+				if m == l {
+					break
+				}
+				if math.Abs(h[m][m-1])*(math.Abs(q)+math.Abs(r)) <
+					epsilon*(math.Abs(p)*(math.Abs(h[m-1][m-1])+math.Abs(z)+math.Abs(h[m+1][m+1]))) {
+					break
+				}
+				m--
+			}
+
+			for i := m + 2; i <= n; i++ {
+				h[i][i-2] = 0.
+				if i > m+2 {
+					h[i][i-3] = 0.
+				}
+			}
+
+			// Double QR step involving rows l:n and columns m:n.
+			for k := m; k <= n-1; k++ {
+				notlast := k != n-1
+				if k != m {
+					p = h[k][k-1]
+					q = h[k+1][k-1]
+					r = 0.
+					if notlast {
+						r = h[k+2][k-1]
+					}
+					x = math.Abs(p) + math.Abs(q) + math.Abs(r)
+					if x == 0. {
+						continue
+					}
+					p /= x
+					q /= x
+					r /= x
+				}
+
+				s = math.Sqrt(p*p + q*q + r*r)
+				if p < 0 {
+					s = -s
+				}
+				if s == 0. {
+					continue
+				}
+				if k != m {
+					h[k][k-1] = -s * x
+				} else if l != m {
+					h[k][k-1] = -h[k][k-1]
+				}
+				p += s
+				x = p / s
+				y = q / s
+				z = r / s
+				q /= p
+				r /= p
+
+				// Row modification.
+				for j := k; j < nn; j++ {
+					p = h[k][j] + q*h[k+1][j]
+					if notlast {
+						p += r * h[k+2][j]
+						h[k+2][j] -= p * z
+					}
+					h[k][j] -= p * x
+					h[k+1][j] -= p * y
+				}
+				// Column modification.
+				for i := 0; i <= min(n, k+3); i++ {
+					p = x*h[i][k] + y*h[i][k+1]
+					if notlast {
+						p += z * h[i][k+2]
+						h[i][k+2] -= p * r
+					}
+					h[i][k] -= p
+					h[i][k+1] -= p * q
+				}
+				// Accumulate transformations.
+				for i := low; i <= high; i++ {
+					p = x*v[i][k] + y*v[i][k+1]
+					if notlast {
+						p += z * v[i][k+2]
+						v[i][k+2] -= p * r
+					}
+					v[i][k] -= p
+					v[i][k+1] -= p * q
+				}
+			}
+		}
+	}
+
+	// Backsubstitute to find vectors of upper triangular form.
+	if norm == 0. {
+		return d, e, nil
+	}
+
+	for n = nn - 1; n >= 0; n-- {
+		p = d[n]
+		q = e[n]
+
+		if q == 0 {
+			// Real vector.
+			l := n
+			h[n][n] = 1.
+			for i := n - 1; i >= 0; i-- {
+				w = h[i][i] - p
+				r = 0.
+				for j := l; j <= n; j++ {
+					r += h[i][j] * h[j][n]
+				}
+				if e[i] < 0. {
+					z = w
+					s = r
+					continue
+				}
+
+				l = i
+				if e[i] == 0. {
+					if w != 0. {
+						h[i][n] = -r / w
+					} else {
+						h[i][n] = -r / (epsilon * norm)
+					}
+				} else {
+					// Solve real equations.
+					x = h[i][i+1]
+					y = h[i+1][i]
+					q = (d[i]-p)*(d[i]-p) + e[i]*e[i]
+					t = (x*s - z*r) / q
+					h[i][n] = t
+					if math.Abs(x) > math.Abs(z) {
+						h[i+1][n] = (-r - w*t) / x
+					} else {
+						h[i+1][n] = (-s - y*t) / z
+					}
+				}
+
+				// Overflow control.
+				t = math.Abs(h[i][n])
+				if (epsilon*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						h[j][n] /= t
+					}
+				}
+			}
+		} else if q < 0 {
+			// Complex vector. The last vector component is chosen
+			// imaginary so that the matrix is triangular.
+			l := n - 1
+			if math.Abs(h[n][n-1]) > math.Abs(h[n-1][n]) {
+				h[n-1][n-1] = q / h[n][n-1]
+				h[n-1][n] = -(h[n][n] - p) / h[n][n-1]
+			} else {
+				c := complex(0., -h[n-1][n]) / complex(h[n-1][n-1]-p, q)
+				h[n-1][n-1] = real(c)
+				h[n-1][n] = imag(c)
+			}
+			h[n][n-1] = 0.
+			h[n][n] = 1.
+
+			for i := n - 2; i >= 0; i-- {
+				ra, sa := 0., 0.
+				for j := l; j <= n; j++ {
+					ra += h[i][j] * h[j][n-1]
+					sa += h[i][j] * h[j][n]
+				}
+				w = h[i][i] - p
+
+				if e[i] < 0. {
+					z = w
+					r = ra
+					s = sa
+					continue
+				}
+
+				l = i
+				if e[i] == 0. {
+					c := complex(-ra, -sa) / complex(w, q)
+					h[i][n-1] = real(c)
+					h[i][n] = imag(c)
+				} else {
+					// Solve complex equations.
+					x = h[i][i+1]
+					y = h[i+1][i]
+					vr := (d[i]-p)*(d[i]-p) + e[i]*e[i] - q*q
+					vi := (d[i] - p) * 2. * q
+					if vr == 0. && vi == 0. {
+						vr = epsilon * norm * (math.Abs(w) + math.Abs(q) + math.Abs(x) + math.Abs(y) + math.Abs(z))
+					}
+					c := complex(x*r-z*ra+q*sa, x*s-z*sa-q*ra) / complex(vr, vi)
+					h[i][n-1] = real(c)
+					h[i][n] = imag(c)
+					if math.Abs(x) > (math.Abs(z) + math.Abs(q)) {
+						h[i+1][n-1] = (-ra - w*h[i][n-1] + q*h[i][n]) / x
+						h[i+1][n] = (-sa - w*h[i][n] - q*h[i][n-1]) / x
+					} else {
+						c := complex(-r-y*h[i][n-1], -s-y*h[i][n]) / complex(z, q)
+						h[i+1][n-1] = real(c)
+						h[i+1][n] = imag(c)
+					}
+				}
+
+				// Overflow control.
+				t = math.Max(math.Abs(h[i][n-1]), math.Abs(h[i][n]))
+				if (epsilon*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						h[j][n-1] /= t
+						h[j][n] /= t
+					}
+				}
+			}
+		}
+	}
+
+	// Back transformation to get eigenvectors of the original matrix.
+	for j := nn - 1; j >= low; j-- {
+		for i := low; i <= high; i++ {
+			z = 0.
+			for k := low; k <= min(j, high); k++ {
+				z += v[i][k] * h[k][j]
+			}
+			v[i][j] = z
+		}
+	}
+
+	return d, e, nil
+}
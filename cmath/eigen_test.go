@@ -0,0 +1,129 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestEigenSym(t *testing.T) {
+	m, _ := StartMatrix(2, 2, 1, 2, 3, 4)
+	if _, err := m.EigenSym(); !errors.Is(err, ErrNotSymmetric) {
+		t.Errorf("incorrect result: expected ErrNotSymmetric, got %v.", err)
+	}
+
+	m, _ = StartMatrix(3, 3, 2, -1, 0, -1, 2, -1, 0, -1, 2)
+	eig, err := m.EigenSym()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+
+	ans := []float64{2 - math.Sqrt2, 2, 2 + math.Sqrt2}
+	for k := range ans {
+		if math.Abs(eig.Values[k]-ans[k]) > 1e-12 {
+			t.Fatalf("incorrect result: expected %v, got %v.", ans, eig.Values)
+		}
+	}
+
+	// A*v = lambda*v and |v| = 1 for each eigenpair.
+	for k := 0; k < 3; k++ {
+		norm := 0.
+		for r := 0; r < 3; r++ {
+			av := 0.
+			for c := 0; c < 3; c++ {
+				av += m.at(r, c) * eig.Vectors.at(c, k)
+			}
+			if math.Abs(av-eig.Values[k]*eig.Vectors.at(r, k)) > 1e-12 {
+				t.Errorf("incorrect result: A*v != lambda*v for lambda = %v.", eig.Values[k])
+			}
+			norm += eig.Vectors.at(r, k) * eig.Vectors.at(r, k)
+		}
+		if math.Abs(norm-1.) > 1e-12 {
+			t.Errorf("incorrect result: expected unit eigenvector, got norm² = %v.", norm)
+		}
+	}
+}
+
+func TestEigen(t *testing.T) {
+	m, _ := StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+	var nsErr NotSquareError
+	if _, err := m.Eigen(); !errors.As(err, &nsErr) {
+		t.Errorf("incorrect result: expected NotSquareError, got %v.", err)
+	}
+
+	tests := []struct {
+		m   Matrix
+		ans []complex128
+	}{
+		{
+			m:   must(StartMatrix(2, 2, 0, -1, 1, 0)),
+			ans: []complex128{-1i, 1i},
+		},
+		{
+			m:   must(StartMatrix(3, 3, 2, 0, 0, 0, 3, 4, 0, 4, 9)),
+			ans: []complex128{1, 2, 11},
+		},
+		{
+			m:   must(StartMatrix(3, 3, 4, 1, 2, 0, 3, 1, 0, 0, 5)),
+			ans: []complex128{3, 4, 5},
+		},
+		{
+			m: must(StartMatrix(4, 4,
+				1, -1, 0, 0,
+				1, 1, 0, 0,
+				0, 0, 2, 1,
+				3, 0, 0, 3)),
+			ans: []complex128{1 - 1i, 1 + 1i, 2, 3},
+		},
+		{
+			// Companion matrix of (x-1)(x-2)(x-3)(x-4)(x-5).
+			m: must(StartMatrix(5, 5,
+				15, -85, 225, -274, 120,
+				1, 0, 0, 0, 0,
+				0, 1, 0, 0, 0,
+				0, 0, 1, 0, 0,
+				0, 0, 0, 1, 0)),
+			ans: []complex128{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, test := range tests {
+		eig, err := test.m.Eigen()
+		if err != nil {
+			t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+		}
+		n := test.m.rows
+		for k := range test.ans {
+			if cmplx.Abs(eig.Values[k]-test.ans[k]) > 1e-10 {
+				t.Fatalf("incorrect result: expected %v, got %v.", test.ans, eig.Values)
+			}
+		}
+		for k := 0; k < n; k++ {
+			vec := eig.Vectors[k]
+			norm := 0.
+			for r := 0; r < n; r++ {
+				av := 0i
+				for c := 0; c < n; c++ {
+					av += complex(test.m.at(r, c), 0.) * vec[c]
+				}
+				if cmplx.Abs(av-eig.Values[k]*vec[r]) > 1e-10 {
+					t.Errorf("incorrect result: A*v != lambda*v for lambda = %v.", eig.Values[k])
+				}
+				norm += real(vec[r] * cmplx.Conj(vec[r]))
+			}
+			if math.Abs(norm-1.) > 1e-12 {
+				t.Errorf("incorrect result: expected unit eigenvector, got norm² = %v.", norm)
+			}
+		}
+	}
+}
+
+// must returns the matrix m and panics if err is not nil. It is
+// used to build the test tables.
+func must(m Matrix, err error) Matrix {
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+)
+
+// Map returns a new matrix with the results of f applied to each
+// element v of the current matrix, m, in row r and column c.
+func (m Matrix) Map(f func(r, c int, v float64) float64) Matrix {
+	result := StartZerosMatrix(m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		drow := result.row(r)
+		for c, v := range m.row(r) {
+			drow[c] = f(r, c, v)
+		}
+	}
+
+	return result
+}
+
+// Apply replaces each element v of the current matrix, m, in row r
+// and column c, with f(r, c, v). It is the in place version of Map.
+func (m Matrix) Apply(f func(r, c int, v float64) float64) {
+	for r := 0; r < m.rows; r++ {
+		row := m.row(r)
+		for c, v := range row {
+			row[c] = f(r, c, v)
+		}
+	}
+}
+
+// Reduce combines the elements of the current matrix, m, row by
+// row, with the function f, starting from the value init: the
+// result is f(...f(f(init, m[0][0]), m[0][1])..., m[r-1][c-1]).
+func (m Matrix) Reduce(init float64, f func(acc, v float64) float64) float64 {
+	acc := init
+	for r := 0; r < m.rows; r++ {
+		for _, v := range m.row(r) {
+			acc = f(acc, v)
+		}
+	}
+
+	return acc
+}
+
+// Trace returns the sum of the elements of the main diagonal of the
+// current matrix, m. A NotSquareError is returned if m is not
+// square.
+func (m Matrix) Trace() (float64, error) {
+	if m.rows != m.cols {
+		return 0., NotSquareError{m.rows, m.cols}
+	}
+
+	t := 0.
+	for k := 0; k < m.rows; k++ {
+		t += m.at(k, k)
+	}
+
+	return t, nil
+}
+
+// aggregate returns, for each row (byRow) or column of m, the value
+// of the function f of its elements.
+func (m Matrix) aggregate(byRow bool, f func(v []float64) float64) []float64 {
+	if byRow {
+		result := make([]float64, m.rows)
+		for r := range result {
+			result[r] = f(m.row(r))
+		}
+		return result
+	}
+
+	result := make([]float64, m.cols)
+	col := make([]float64, m.rows)
+	for c := range result {
+		for r := range col {
+			col[r] = m.at(r, c)
+		}
+		result[c] = f(col)
+	}
+
+	return result
+}
+
+// sum returns the sum of the elements of v.
+func sum(v []float64) float64 {
+	s := 0.
+	for _, x := range v {
+		s += x
+	}
+
+	return s
+}
+
+// mean returns the mean of the elements of v, NaN if v is empty.
+func mean(v []float64) float64 {
+	if len(v) == 0 {
+		return math.NaN()
+	}
+
+	return sum(v) / float64(len(v))
+}
+
+// minimum returns the smallest element of v, NaN if v is empty.
+func minimum(v []float64) float64 {
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	s := v[0]
+	for _, x := range v[1:] {
+		s = math.Min(s, x)
+	}
+
+	return s
+}
+
+// maximum returns the largest element of v, NaN if v is empty.
+func maximum(v []float64) float64 {
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	s := v[0]
+	for _, x := range v[1:] {
+		s = math.Max(s, x)
+	}
+
+	return s
+}
+
+// RowSums returns the sum of the elements of each row of the
+// current matrix, m.
+func (m Matrix) RowSums() []float64 {
+	return m.aggregate(true, sum)
+}
+
+// ColSums returns the sum of the elements of each column of the
+// current matrix, m.
+func (m Matrix) ColSums() []float64 {
+	return m.aggregate(false, sum)
+}
+
+// RowMeans returns the mean of the elements of each row of the
+// current matrix, m. The means are NaN if m has no columns.
+func (m Matrix) RowMeans() []float64 {
+	return m.aggregate(true, mean)
+}
+
+// ColMeans returns the mean of the elements of each column of the
+// current matrix, m. The means are NaN if m has no rows.
+func (m Matrix) ColMeans() []float64 {
+	return m.aggregate(false, mean)
+}
+
+// RowMins returns the smallest element of each row of the current
+// matrix, m. The minimums are NaN if m has no columns, or for the
+// rows with a NaN element.
+func (m Matrix) RowMins() []float64 {
+	return m.aggregate(true, minimum)
+}
+
+// ColMins returns the smallest element of each column of the
+// current matrix, m. The minimums are NaN if m has no rows, or for
+// the columns with a NaN element.
+func (m Matrix) ColMins() []float64 {
+	return m.aggregate(false, minimum)
+}
+
+// RowMaxs returns the largest element of each row of the current
+// matrix, m. The maximums are NaN if m has no columns, or for the
+// rows with a NaN element.
+func (m Matrix) RowMaxs() []float64 {
+	return m.aggregate(true, maximum)
+}
+
+// ColMaxs returns the largest element of each column of the
+// current matrix, m. The maximums are NaN if m has no rows, or for
+// the columns with a NaN element.
+func (m Matrix) ColMaxs() []float64 {
+	return m.aggregate(false, maximum)
+}
+
+// Row returns a copy of the row r of the current matrix, m. Use
+// RowView for a slice that shares the elements of m. An error is
+// generated if the row does not exist.
+func (m Matrix) Row(r int) ([]float64, error) {
+	if r < 0 || r >= m.rows {
+		return nil, fmt.Errorf("there is not row %d in this matrix", r)
+	}
+
+	row := make([]float64, m.cols)
+	copy(row, m.row(r))
+
+	return row, nil
+}
+
+// Col returns a copy of the column c of the current matrix, m. An
+// error is generated if the column does not exist.
+func (m Matrix) Col(c int) ([]float64, error) {
+	if c < 0 || c >= m.cols {
+		return nil, fmt.Errorf("there is not column %d in this matrix", c)
+	}
+
+	col := make([]float64, m.rows)
+	for r := range col {
+		col[r] = m.at(r, c)
+	}
+
+	return col, nil
+}
+
+// SwapRows swaps the rows i and j of the current matrix, m. An
+// error is generated if a row does not exist.
+func (m Matrix) SwapRows(i, j int) error {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.rows {
+		return fmt.Errorf("there are not rows %d and %d in this matrix", i, j)
+	}
+
+	ri, rj := m.row(i), m.row(j)
+	for c := range ri {
+		ri[c], rj[c] = rj[c], ri[c]
+	}
+
+	return nil
+}
+
+// SwapCols swaps the columns i and j of the current matrix, m. An
+// error is generated if a column does not exist.
+func (m Matrix) SwapCols(i, j int) error {
+	if i < 0 || i >= m.cols || j < 0 || j >= m.cols {
+		return fmt.Errorf("there are not columns %d and %d in this matrix", i, j)
+	}
+
+	for r := 0; r < m.rows; r++ {
+		row := m.row(r)
+		row[i], row[j] = row[j], row[i]
+	}
+
+	return nil
+}
+
+// ScaleRow multiplies the row r of the current matrix, m, by the
+// real constant s. An error is generated if the row does not exist.
+func (m Matrix) ScaleRow(r int, s float64) error {
+	if r < 0 || r >= m.rows {
+		return fmt.Errorf("there is not row %d in this matrix", r)
+	}
+
+	row := m.row(r)
+	for c := range row {
+		row[c] *= s
+	}
+
+	return nil
+}
+
+// ScaleCol multiplies the column c of the current matrix, m, by the
+// real constant s. An error is generated if the column does not
+// exist.
+func (m Matrix) ScaleCol(c int, s float64) error {
+	if c < 0 || c >= m.cols {
+		return fmt.Errorf("there is not column %d in this matrix", c)
+	}
+
+	for r := 0; r < m.rows; r++ {
+		m.elems[r*m.stride+c] *= s
+	}
+
+	return nil
+}
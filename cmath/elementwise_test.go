@@ -0,0 +1,112 @@
+package cmath
+
+import (
+	"math"
+	"testing"
+)
+
+func equalSlices(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestMapApplyReduce(t *testing.T) {
+	m := must(StartMatrix(2, 3, 1, 2, 3, 4, 5, 6))
+
+	sq := m.Map(func(r, c int, v float64) float64 { return v * v })
+	ans := must(StartMatrix(2, 3, 1, 4, 9, 16, 25, 36))
+	if !sq.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, sq)
+	}
+	if m.at(1, 2) != 6 {
+		t.Error("incorrect result: Map must not change the matrix.")
+	}
+
+	m.Apply(func(r, c int, v float64) float64 { return float64(10*r+c) + v })
+	ans = must(StartMatrix(2, 3, 1, 3, 5, 14, 16, 18))
+	if !m.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, m)
+	}
+
+	prod := ans.Reduce(1, func(acc, v float64) float64 { return acc * v })
+	if prod != 1*3*5*14*16*18 {
+		t.Errorf("incorrect result: expected %v, got %v.", 1*3*5*14*16*18, prod)
+	}
+}
+
+func TestAggregates(t *testing.T) {
+	m := must(StartMatrix(2, 3, 1, -2, 3, 4, 5, -6))
+
+	tests := []struct {
+		name string
+		got  []float64
+		ans  []float64
+	}{
+		{"RowSums", m.RowSums(), []float64{2, 3}},
+		{"ColSums", m.ColSums(), []float64{5, 3, -3}},
+		{"RowMeans", m.RowMeans(), []float64{2. / 3, 1}},
+		{"ColMeans", m.ColMeans(), []float64{2.5, 1.5, -1.5}},
+		{"RowMins", m.RowMins(), []float64{-2, -6}},
+		{"ColMins", m.ColMins(), []float64{1, -2, -6}},
+		{"RowMaxs", m.RowMaxs(), []float64{3, 5}},
+		{"ColMaxs", m.ColMaxs(), []float64{4, 5, 3}},
+	}
+	for _, test := range tests {
+		if !equalSlices(test.got, test.ans) {
+			t.Errorf("incorrect result: %s expected %v, got %v.", test.name, test.ans, test.got)
+		}
+	}
+
+	if means := StartZerosMatrix(2, 0).RowMeans(); !math.IsNaN(means[0]) {
+		t.Errorf("incorrect result: expected NaN, got %v.", means[0])
+	}
+
+	tr, err := must(StartMatrix(2, 2, 1, 2, 3, 4)).Trace()
+	if err != nil || tr != 5 {
+		t.Errorf("incorrect result: expected trace 5, got %v.", tr)
+	}
+	if _, err := m.Trace(); err == nil {
+		t.Error("incorrect result: expected error, matrix is not square.")
+	}
+}
+
+func TestRowColOperations(t *testing.T) {
+	m := must(StartMatrix(3, 2, 1, 2, 3, 4, 5, 6))
+
+	row, _ := m.Row(1)
+	col, _ := m.Col(1)
+	if !equalSlices(row, []float64{3, 4}) || !equalSlices(col, []float64{2, 4, 6}) {
+		t.Errorf("incorrect result: expected row [3 4] and column [2 4 6], got %v and %v.", row, col)
+	}
+	row[0] = 0
+	if m.at(1, 0) != 3 {
+		t.Error("incorrect result: Row must return a copy.")
+	}
+
+	m.SwapRows(0, 2)
+	m.SwapCols(0, 1)
+	m.ScaleRow(1, 2)
+	m.ScaleCol(0, -1)
+	ans := must(StartMatrix(3, 2, -6, 5, -8, 6, -2, 1))
+	if !m.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, m)
+	}
+
+	if err := m.SwapRows(0, 3); err == nil {
+		t.Error("incorrect result: expected error, row out of range.")
+	}
+	if err := m.ScaleCol(2, 1); err == nil {
+		t.Error("incorrect result: expected error, column out of range.")
+	}
+	if _, err := m.Col(-1); err == nil {
+		t.Error("incorrect result: expected error, column out of range.")
+	}
+}
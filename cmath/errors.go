@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NotSquareError is returned by the operations that are only
+// defined for square matrices, such as determinants, when the
+// matrix passed has a different number of rows and columns.
+type NotSquareError struct {
+	Rows int
+	Cols int
+}
+
+// Error implements the error interface.
+func (e NotSquareError) Error() string {
+	return fmt.Sprintf("matrix %dx%d is not square", e.Rows, e.Cols)
+}
+
+// ErrSingular is returned when a matrix is singular, or so close
+// to singular that the result of the operation would be dominated
+// by rounding errors (Inf or NaN elements).
+var ErrSingular = errors.New("matrix is singular or nearly singular")
+
+// ErrNotSymmetric is returned by the operations that are only
+// defined for symmetric matrices.
+var ErrNotSymmetric = errors.New("matrix is not symmetric")
+
+// ConvergenceError is returned by the iterative algorithms when
+// the iteration limit is reached, or the method breaks down, before
+// the required precision. Iterations is the number of iterations
+// done.
+type ConvergenceError struct {
+	Method     string
+	Iterations int
+}
+
+// Error implements the error interface.
+func (e ConvergenceError) Error() string {
+	return fmt.Sprintf("%s did not converge after %d iterations", e.Method, e.Iterations)
+}
+
+// ErrDefective is returned by Diagonalize when the matrix does not
+// have a complete set of linearly independent eigenvectors.
+var ErrDefective = errors.New("matrix is defective and cannot be diagonalized")
+
+// ErrComplexEigenvalues is returned by the operations that need
+// real eigenvalues when the matrix has complex ones.
+var ErrComplexEigenvalues = errors.New("matrix has complex eigenvalues")
+
+// ErrRankDeficient is returned by the least squares solvers when the
+// columns of the coefficient matrix are linearly dependent.
+var ErrRankDeficient = errors.New("matrix is rank deficient")
+
+// NotPositiveDefiniteError is returned by the Cholesky factorization
+// when the pivot of row Pivot, Value, is not positive, which means
+// the matrix is not positive definite.
+type NotPositiveDefiniteError struct {
+	Pivot int
+	Value float64
+}
+
+// Error implements the error interface.
+func (e NotPositiveDefiniteError) Error() string {
+	return fmt.Sprintf("matrix is not positive definite: pivot %d is %g", e.Pivot, e.Value)
+}
+
+// ErrDimension is wrapped by the errors of the operations that
+// receive matrices with incompatible dimensions.
+var ErrDimension = errors.New("matrix dimensions do not match")
+
+// NotDiagonallyDominantError is returned by the solvers that do not
+// pivot, such as the Thomas algorithm, when the magnitude of the
+// diagonal element of row Row is smaller than the sum of the
+// magnitudes of the other elements of the row. Without diagonal
+// dominance the elimination may divide by zero or amplify rounding
+// errors.
+type NotDiagonallyDominantError struct {
+	Row int
+}
+
+// Error implements the error interface.
+func (e NotDiagonallyDominantError) Error() string {
+	return fmt.Sprintf("matrix is not diagonally dominant at row %d", e.Row)
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"math"
+)
+
+// Coefficients of the [m/m] Padé approximants of exp(x), and the
+// largest 1-norms for which they reach double precision, from
+// N. J. Higham, "The scaling and squaring method for the matrix
+// exponential revisited", SIAM J. Matrix Anal. Appl., 2005.
+var (
+	padeCoefs = map[int][]float64{
+		3: {120, 60, 12, 1},
+		5: {30240, 15120, 3360, 420, 30, 1},
+		7: {17297280, 8648640, 1995840, 277200, 25200, 1512, 56, 1},
+		9: {17643225600, 8821612800, 2075673600, 302702400, 30270240,
+			2162160, 110880, 3960, 90, 1},
+		13: {64764752532480000, 32382376266240000, 7771770303897600,
+			1187353796428800, 129060195264000, 10559470521600,
+			670442572800, 33522128640, 1323241920, 40840800, 960960,
+			16380, 182, 1},
+	}
+	padeTheta = []struct {
+		m     int
+		theta float64
+	}{
+		{3, 1.495585217958292e-2},
+		{5, 2.539398330063230e-1},
+		{7, 9.504178996162932e-1},
+		{9, 2.097847961257068},
+	}
+)
+
+const padeTheta13 = 5.371920351148152
+
+/*
+Expm returns the matrix exponential e^m of the current matrix, m,
+by the scaling and squaring method with Padé approximants. The
+lowest approximant order that reaches double precision is chosen
+from the 1-norm of m; above the limit of order 13, m is scaled by
+2^-s, the approximant is evaluated and the result is squared s
+times. A NotSquareError is returned if m is not square.
+
+The solution of the linear system x' = A*x is x(t) = e^(A*t)*x(0).
+*/
+func (m Matrix) Expm() (Matrix, error) {
+	if m.rows != m.cols {
+		return Matrix{}, NotSquareError{m.rows, m.cols}
+	}
+
+	norm := m.Norm1()
+	for _, p := range padeTheta {
+		if norm <= p.theta {
+			return padeExp(m, p.m, 0)
+		}
+	}
+
+	s := 0
+	if norm > padeTheta13 {
+		s = int(math.Ceil(math.Log2(norm / padeTheta13)))
+	}
+	a := m
+	if s > 0 {
+		a = m.RealProduct(math.Ldexp(1, -s))
+	}
+	return padeExp(a, 13, s)
+}
+
+// padeExp evaluates the [q/q] Padé approximant of the exponential
+// of a and squares the result s times.
+func padeExp(a Matrix, q, s int) (Matrix, error) {
+	b := padeCoefs[q]
+	n := a.rows
+	id := StartIdentityMatrix(n)
+	a2, _ := a.Product(a)
+
+	var u, v Matrix
+	if q < 13 {
+		// U = A*(b1*I + b3*A² + ...), V = b0*I + b2*A² + ...
+		odd := id.RealProduct(b[1])
+		v = id.RealProduct(b[0])
+		pow := id
+		for k := 2; k <= q; k += 2 {
+			pow, _ = pow.Product(a2)
+			addScaled(v, pow, b[k])
+			addScaled(odd, pow, b[k+1])
+		}
+		u, _ = a.Product(odd)
+	} else {
+		a4, _ := a2.Product(a2)
+		a6, _ := a4.Product(a2)
+
+		t := a6.RealProduct(b[13])
+		addScaled(t, a4, b[11])
+		addScaled(t, a2, b[9])
+		t, _ = a6.Product(t)
+		addScaled(t, a6, b[7])
+		addScaled(t, a4, b[5])
+		addScaled(t, a2, b[3])
+		addScaled(t, id, b[1])
+		u, _ = a.Product(t)
+
+		v = a6.RealProduct(b[12])
+		addScaled(v, a4, b[10])
+		addScaled(v, a2, b[8])
+		v, _ = a6.Product(v)
+		addScaled(v, a6, b[6])
+		addScaled(v, a4, b[4])
+		addScaled(v, a2, b[2])
+		addScaled(v, id, b[0])
+	}
+
+	// (V - U) * X = (V + U)
+	num, _ := v.Add(u)
+	den, _ := v.Sub(u)
+	lu, _ := den.LU()
+	x, err := lu.SolveMatrix(num)
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	for ; s > 0; s-- {
+		x, _ = x.Product(x)
+	}
+
+	return x, nil
+}
+
+// maxSqrtmIterations is the iteration limit of Sqrtm.
+const maxSqrtmIterations = 100
+
+/*
+Sqrtm returns the principal square root X of the current matrix,
+m, so that X*X = m, by the Denman-Beavers iteration
+
+	Y(k+1) = (Y(k) + Z(k)⁻¹)/2,  Z(k+1) = (Z(k) + Y(k)⁻¹)/2
+
+with Y(0) = m and Z(0) = I, where Y converges to the square root
+and Z to its inverse. The principal root exists when m has no
+eigenvalues on the closed negative real axis.
+
+A NotSquareError is returned if m is not square, ErrSingular if an
+iterate becomes singular and a ConvergenceError if the iteration
+does not settle.
+*/
+func (m Matrix) Sqrtm() (Matrix, error) {
+	if m.rows != m.cols {
+		return Matrix{}, NotSquareError{m.rows, m.cols}
+	}
+
+	y := m
+	z := StartIdentityMatrix(m.rows)
+	prev := math.Inf(1)
+	for k := 0; k < maxSqrtmIterations; k++ {
+		yi, err := y.Inverse()
+		if err != nil {
+			return Matrix{}, err
+		}
+		zi, err := z.Inverse()
+		if err != nil {
+			return Matrix{}, err
+		}
+
+		ny, _ := y.Add(zi)
+		ny = ny.RealProduct(0.5)
+		nz, _ := z.Add(yi)
+		nz = nz.RealProduct(0.5)
+
+		// The iteration converges quadratically, so it stops when
+		// the change reaches the rounding level or stops shrinking
+		// after getting close to it.
+		diff, _ := ny.Sub(y)
+		y, z = ny, nz
+		rel := diff.Norm1() / y.Norm1()
+		if rel <= float64(m.rows)*epsilon || rel < 1e-8 && rel >= prev {
+			return y, nil
+		}
+		prev = rel
+	}
+
+	return Matrix{}, ConvergenceError{"Sqrtm", maxSqrtmIterations}
+}
+
+// Nodes and weights of the 8 point Gauss-Legendre quadrature in
+// [-1, 1], used by Logm.
+var (
+	logmNodes   = []float64{0.1834346424956498, 0.5255324099163290, 0.7966664774136267, 0.9602898564975363}
+	logmWeights = []float64{0.3626837833783620, 0.3137066458778873, 0.2223810344533745, 0.1012285362903763}
+)
+
+// maxLogmRoots is the limit of square roots taken by Logm.
+const maxLogmRoots = 64
+
+/*
+Logm returns the principal logarithm X of the current matrix, m, so
+that e^X = m, by the inverse scaling and squaring method: square
+roots are taken, m^(1/2^k), until it is close to the identity, the
+logarithm of I + E is computed by the [8/8] Padé approximant, in
+the form of the 8 point Gauss-Legendre quadrature of
+
+	log(I + E) = ∫ E*(I + t*E)⁻¹ dt,  0 ≤ t ≤ 1
+
+and the result is multiplied by 2^k. The principal logarithm
+exists when m has no eigenvalues on the closed negative real axis.
+
+A NotSquareError is returned if m is not square, and the errors of
+Sqrtm if a square root can not be taken.
+*/
+func (m Matrix) Logm() (Matrix, error) {
+	if m.rows != m.cols {
+		return Matrix{}, NotSquareError{m.rows, m.cols}
+	}
+
+	n := m.rows
+	id := StartIdentityMatrix(n)
+	a := m
+	k := 0
+	for {
+		e, _ := a.Sub(id)
+		if e.Norm1() <= 0.25 {
+			break
+		}
+		if k == maxLogmRoots {
+			return Matrix{}, ConvergenceError{"Logm", k}
+		}
+		var err error
+		if a, err = a.Sqrtm(); err != nil {
+			return Matrix{}, err
+		}
+		k++
+	}
+
+	e, _ := a.Sub(id)
+	result := StartZerosMatrix(n, n)
+	for j, x := range logmNodes {
+		for _, t := range []float64{(1 - x) / 2, (1 + x) / 2} {
+			d := id.RealProduct(1.)
+			addScaled(d, e, t)
+			lu, _ := d.LU()
+			q, err := lu.SolveMatrix(e)
+			if err != nil {
+				return Matrix{}, err
+			}
+			addScaled(result, q, logmWeights[j]/2)
+		}
+	}
+
+	return result.RealProduct(math.Ldexp(1, k)), nil
+}
+
+/*
+Pow returns the current matrix, m, raised to the power p. Integer
+powers are computed by repeated squaring, with the inverse of m for
+negative p and the identity for p = 0. Other powers are computed as
+e^(p*log(m)), so m must satisfy the conditions of Logm.
+
+A NotSquareError is returned if m is not square and ErrSingular if
+p is negative and m is singular.
+*/
+func (m Matrix) Pow(p float64) (Matrix, error) {
+	if m.rows != m.cols {
+		return Matrix{}, NotSquareError{m.rows, m.cols}
+	}
+
+	if p != math.Trunc(p) || math.Abs(p) > 1<<62 {
+		l, err := m.Logm()
+		if err != nil {
+			return Matrix{}, err
+		}
+		return l.RealProduct(p).Expm()
+	}
+
+	base := m
+	if p < 0 {
+		var err error
+		if base, err = m.Inverse(); err != nil {
+			return Matrix{}, err
+		}
+		p = -p
+	}
+
+	result := StartIdentityMatrix(m.rows)
+	for e := uint64(p); e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result, _ = result.Product(base)
+		}
+		if e > 1 {
+			base, _ = base.Product(base)
+		}
+	}
+
+	return result, nil
+}
+
+// addScaled adds s*a to dst, that must have the dimensions of a.
+func addScaled(dst, a Matrix, s float64) {
+	for r := 0; r < dst.rows; r++ {
+		d := dst.row(r)
+		for c, v := range a.row(r) {
+			d[c] += s * v
+		}
+	}
+}
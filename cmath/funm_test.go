@@ -0,0 +1,147 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// closeTo reports whether a and b have the same dimensions and
+// their elements differ by at most tol.
+func closeTo(a, b Matrix, tol float64) bool {
+	if a.rows != b.rows || a.cols != b.cols {
+		return false
+	}
+	for r := 0; r < a.rows; r++ {
+		for c := 0; c < a.cols; c++ {
+			if math.Abs(a.at(r, c)-b.at(r, c)) > tol {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func rotation(theta float64) Matrix {
+	s, c := math.Sincos(theta)
+	return must(StartMatrix(2, 2, c, -s, s, c))
+}
+
+func TestExpm(t *testing.T) {
+	// e^(θJ) is the rotation by θ, for the generator J = [0 -1; 1 0].
+	for _, theta := range []float64{1e-3, 0.1, 0.5, 1.5, 3, 10, 40} {
+		gen := must(StartMatrix(2, 2, 0, -theta, theta, 0))
+		e, err := gen.Expm()
+		if err != nil {
+			t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+		}
+		if !closeTo(e, rotation(theta), 1e-13*math.Max(1, theta)) {
+			t.Errorf("incorrect result: θ = %v expected \n%v, got\n%v.", theta, rotation(theta), e)
+		}
+	}
+
+	// Nilpotent: e^N = I + N.
+	n := must(StartMatrix(3, 3, 0, 1, 2, 0, 0, 3, 0, 0, 0))
+	e, _ := n.Expm()
+	ans := must(StartMatrix(3, 3, 1, 1, 3.5, 0, 1, 3, 0, 0, 1))
+	if !closeTo(e, ans, 1e-14) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, e)
+	}
+
+	d := must(StartMatrix(2, 2, -2, 0, 0, 5))
+	e, _ = d.Expm()
+	if math.Abs(e.at(0, 0)-math.Exp(-2)) > 1e-15 || math.Abs(e.at(1, 1)-math.Exp(5))/math.Exp(5) > 1e-14 {
+		t.Errorf("incorrect result: expected diag(e⁻², e⁵), got\n%v.", e)
+	}
+
+	var nse NotSquareError
+	if _, err := StartZerosMatrix(2, 3).Expm(); !errors.As(err, &nse) {
+		t.Errorf("incorrect result: expected NotSquareError, got %v.", err)
+	}
+}
+
+func TestSqrtm(t *testing.T) {
+	a := must(StartMatrix(2, 2, 5, 4, 4, 5))
+	s, err := a.Sqrtm()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans := must(StartMatrix(2, 2, 2, 1, 1, 2))
+	if !closeTo(s, ans, 1e-13) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, s)
+	}
+
+	// The square root of a rotation by θ is the rotation by θ/2.
+	s, err = rotation(2).Sqrtm()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	if !closeTo(s, rotation(1), 1e-13) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", rotation(1), s)
+	}
+
+	if _, err := must(StartMatrix(2, 2, 1, 2, 2, 4)).Sqrtm(); !errors.Is(err, ErrSingular) {
+		t.Errorf("incorrect result: expected ErrSingular, got %v.", err)
+	}
+}
+
+func TestLogm(t *testing.T) {
+	for _, theta := range []float64{0.1, 1, 2.5} {
+		l, err := rotation(theta).Logm()
+		if err != nil {
+			t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+		}
+		gen := must(StartMatrix(2, 2, 0, -theta, theta, 0))
+		if !closeTo(l, gen, 1e-12) {
+			t.Errorf("incorrect result: θ = %v expected \n%v, got\n%v.", theta, gen, l)
+		}
+	}
+
+	a := must(StartMatrix(3, 3, 4, 1, 0, 1, 3, 1, 0, 1, 2))
+	l, err := a.Logm()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	e, _ := l.Expm()
+	if !closeTo(e, a, 1e-12) {
+		t.Errorf("incorrect result: expected e^log(A) = A, got\n%v.", e)
+	}
+}
+
+func TestPow(t *testing.T) {
+	a := must(StartMatrix(2, 2, 1, 1, 1, 0))
+	p, _ := a.Pow(10)
+	// Fibonacci numbers.
+	ans := must(StartMatrix(2, 2, 89, 55, 55, 34))
+	if !p.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, p)
+	}
+
+	p, _ = a.Pow(0)
+	if !p.IsEqual(StartIdentityMatrix(2)) {
+		t.Errorf("incorrect result: expected identity, got\n%v.", p)
+	}
+
+	p, _ = a.Pow(-1)
+	inv, _ := a.Inverse()
+	if !closeTo(p, inv, 1e-15) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", inv, p)
+	}
+
+	p, err := rotation(1.2).Pow(0.5)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	if !closeTo(p, rotation(0.6), 1e-12) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", rotation(0.6), p)
+	}
+
+	spd := must(StartMatrix(2, 2, 5, 4, 4, 5))
+	p, _ = spd.Pow(1.5)
+	// Eigenvalues 9 and 1 with eigenvectors (1, 1) and (1, -1).
+	ans = must(StartMatrix(2, 2, 14, 13, 13, 14))
+	if !closeTo(p, ans, 1e-11) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, p)
+	}
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Float is the constraint of the real element types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Complex is the constraint of the complex element types.
+type Complex interface {
+	~complex64 | ~complex128
+}
+
+// Number is the constraint of the element types of MatrixOf and
+// VectorOf.
+type Number interface {
+	Float | Complex
+}
+
+/*
+MatrixOf is a matrix with elements of any Number type, such as
+float32 for memory-bound work or complex128 for phasors. It has the
+operations that do not depend on the element type: addition,
+subtraction, products and transpose.
+
+Matrix and CMatrix are defined as MatrixOf[float64] and
+MatrixOf[complex128], so they share its storage and code, and add
+the decompositions of their own element type. The conversions
+between them, such as MatrixOf[float64](m), do not copy the
+elements.
+*/
+type MatrixOf[T Number] struct {
+	elems  []T
+	rows   int
+	cols   int
+	stride int
+}
+
+// StartMatrixOf starts a matrix with r rows and c columns with the
+// elements passed by list e. An error is generated if r or c is
+// negative or if the number of elements does not match the product
+// r*c.
+func StartMatrixOf[T Number](r int, c int, e ...T) (MatrixOf[T], error) {
+	if err := checkDims(r, c); err != nil {
+		return MatrixOf[T]{}, err
+	}
+	if r*c != len(e) {
+		return MatrixOf[T]{}, fmt.Errorf("rows (%d) x columns (%d) is different from the number of matrix elements (%d)", r, c, len(e))
+	}
+
+	m := StartZerosMatrixOf[T](r, c)
+	copy(m.elems, e)
+
+	return m, nil
+}
+
+// StartZerosMatrixOf starts a matrix with zero elements with r rows
+// and c columns. r and c must not be negative, otherwise it panics.
+func StartZerosMatrixOf[T Number](r, c int) MatrixOf[T] {
+	if err := checkDims(r, c); err != nil {
+		panic(err.Error())
+	}
+	return MatrixOf[T]{
+		elems:  make([]T, r*c),
+		rows:   r,
+		cols:   c,
+		stride: c,
+	}
+}
+
+// ToFloat64 returns the Matrix with the elements of the real
+// matrix m converted to float64.
+func ToFloat64[T Float](m MatrixOf[T]) Matrix {
+	result := newMatrix(m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.row(r) {
+			result.elems[r*result.stride+c] = float64(v)
+		}
+	}
+
+	return result
+}
+
+// FromFloat64 returns the matrix with the elements of the Matrix m
+// converted to the real type T.
+func FromFloat64[T Float](m Matrix) MatrixOf[T] {
+	result := StartZerosMatrixOf[T](m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.row(r) {
+			result.elems[r*result.stride+c] = T(v)
+		}
+	}
+
+	return result
+}
+
+// GetElement returns the matrix elements of the row r and column
+// c. An error is generated if the requested element exceeds the
+// matrix size.
+func (m MatrixOf[T]) GetElement(r, c int) (T, error) {
+	if r >= 0 && r < m.rows && c >= 0 && c < m.cols {
+		return m.elems[r*m.stride+c], nil
+	}
+	return 0, fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+}
+
+// SetElement changes the matrix elements of row r and column c
+// with value v. An error is generated if the requested element
+// exceeds the matrix size.
+func (m *MatrixOf[T]) SetElement(r, c int, v T) error {
+	if r >= 0 && r < m.rows && c >= 0 && c < m.cols {
+		m.elems[r*m.stride+c] = v
+		return nil
+	}
+	return fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+}
+
+// Dims returns the number of rows and columns of the current
+// matrix, m.
+func (m MatrixOf[T]) Dims() (int, int) {
+	return m.rows, m.cols
+}
+
+// row returns the row r of m, sharing its elements.
+func (m MatrixOf[T]) row(r int) []T {
+	return m.elems[r*m.stride : r*m.stride+m.cols]
+}
+
+// at returns the element [r][c] of m, without bounds checking.
+func (m MatrixOf[T]) at(r, c int) T {
+	return m.elems[r*m.stride+c]
+}
+
+// IsEqual compares the current matrix, m, with the matrix other
+// and returns true if they are equal.
+func (m MatrixOf[T]) IsEqual(other MatrixOf[T]) bool {
+	if m.cols != other.cols || m.rows != other.rows {
+		return false
+	}
+
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			if m.at(r, c) != other.at(r, c) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Add returns the addition of the current matrix, m, by the
+// other matrix. An error is generated if the arrays have
+// different sizes.
+func (m MatrixOf[T]) Add(other MatrixOf[T]) (MatrixOf[T], error) {
+	if m.rows != other.rows || m.cols != other.cols {
+		return MatrixOf[T]{}, fmt.Errorf("matrix %dx%d plus %dx%d: %w", m.rows, m.cols, other.rows, other.cols, ErrDimension)
+	}
+
+	result := StartZerosMatrixOf[T](m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		dst, a, b := result.row(r), m.row(r), other.row(r)
+		for c := range dst {
+			dst[c] = a[c] + b[c]
+		}
+	}
+
+	return result, nil
+}
+
+// Sub returns the subtraction of the current matrix, m, by the
+// other matrix. An error is generated if the arrays have different
+// sizes.
+func (m MatrixOf[T]) Sub(other MatrixOf[T]) (MatrixOf[T], error) {
+	if m.rows != other.rows || m.cols != other.cols {
+		return MatrixOf[T]{}, fmt.Errorf("matrix %dx%d minus %dx%d: %w", m.rows, m.cols, other.rows, other.cols, ErrDimension)
+	}
+
+	result := StartZerosMatrixOf[T](m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		dst, a, b := result.row(r), m.row(r), other.row(r)
+		for c := range dst {
+			dst[c] = a[c] - b[c]
+		}
+	}
+
+	return result, nil
+}
+
+// ScalarProduct returns the product of the current matrix, m, by
+// the constant s.
+func (m MatrixOf[T]) ScalarProduct(s T) MatrixOf[T] {
+	result := StartZerosMatrixOf[T](m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		dst := result.row(r)
+		for c, v := range m.row(r) {
+			dst[c] = v * s
+		}
+	}
+
+	return result
+}
+
+// Product returns the product between the current matrix, m, and
+// the matrix other. An error is generated if the number of columns
+// of m is different from the number of rows of other.
+func (m MatrixOf[T]) Product(other MatrixOf[T]) (MatrixOf[T], error) {
+	if m.cols != other.rows {
+		return MatrixOf[T]{}, fmt.Errorf("matrix %dx%d times %dx%d: %w", m.rows, m.cols, other.rows, other.cols, ErrDimension)
+	}
+
+	result := StartZerosMatrixOf[T](m.rows, other.cols)
+	for r := 0; r < m.rows; r++ {
+		dst := result.row(r)
+		for k, a := range m.row(r) {
+			for c, b := range other.row(k) {
+				dst[c] += a * b
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// MulVec returns the product of the current matrix, m, by the
+// column vector x. An error is generated if the length of x is
+// different from the number of columns of m.
+func (m MatrixOf[T]) MulVec(x []T) ([]T, error) {
+	if len(x) != m.cols {
+		return nil, fmt.Errorf("matrix %dx%d times vector of length %d: %w", m.rows, m.cols, len(x), ErrDimension)
+	}
+
+	y := make([]T, m.rows)
+	for r := 0; r < m.rows; r++ {
+		var s T
+		for c, v := range m.row(r) {
+			s += v * x[c]
+		}
+		y[r] = s
+	}
+
+	return y, nil
+}
+
+// Transpose returns the transpose of the current matrix, m. The
+// elements of complex matrices are not conjugated.
+func (m MatrixOf[T]) Transpose() MatrixOf[T] {
+	result := StartZerosMatrixOf[T](m.cols, m.rows)
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.row(r) {
+			result.elems[c*result.stride+r] = v
+		}
+	}
+
+	return result
+}
+
+// String creates formatted output for the array and makes the
+// MatrixOf part of the types that satisfy the fmt.Stringer
+// interface.
+func (m MatrixOf[T]) String() string {
+	if len(m.elems) > 0 {
+		str := "["
+		for r := 0; r < m.rows; r++ {
+			for c := 0; c < m.cols; c++ {
+				str += fmt.Sprintf("%+3.2f ", m.at(r, c))
+			}
+			str = str[:len(str)-1] + "]\n["
+		}
+		str = str[:len(str)-1]
+
+		return str
+	}
+	return "[]\n"
+}
+
+// VectorOf is a three-dimensional vector with elements of any
+// Number type. Vector is the VectorOf[float64].
+type VectorOf[T Number] [3]T
+
+// X return the x component of the vector.
+func (v VectorOf[T]) X() T {
+	return v[0]
+}
+
+// Y return the y component of the vector.
+func (v VectorOf[T]) Y() T {
+	return v[1]
+}
+
+// Z return the z component of the vector.
+func (v VectorOf[T]) Z() T {
+	return v[2]
+}
+
+// Norm returns the norm (module) of the current vector, the
+// square root of the sum of the squared magnitudes of the
+// components.
+func (v VectorOf[T]) Norm() float64 {
+	return math.Sqrt(abs2(v[0]) + abs2(v[1]) + abs2(v[2]))
+}
+
+// Add returns the vector resulting from the sum of the current
+// vector, v, by the other vector.
+func (v VectorOf[T]) Add(other VectorOf[T]) VectorOf[T] {
+	return VectorOf[T]{v[0] + other[0], v[1] + other[1], v[2] + other[2]}
+}
+
+// Sub returns the vector resulting from the subtraction of the
+// current vector, v, by the other vector.
+func (v VectorOf[T]) Sub(other VectorOf[T]) VectorOf[T] {
+	return VectorOf[T]{v[0] - other[0], v[1] - other[1], v[2] - other[2]}
+}
+
+// IsEqual returns true if the current vector, v, is equal to
+// the other vector.
+func (v VectorOf[T]) IsEqual(other VectorOf[T]) bool {
+	return v == other
+}
+
+// RealProd returns the resultant vector of the product of the
+// current vector, v, by the constant.
+func (v VectorOf[T]) RealProd(real T) VectorOf[T] {
+	return VectorOf[T]{v[0] * real, v[1] * real, v[2] * real}
+}
+
+// DotProd returns the dot product of the current vector, v, by
+// the other vector. The components of complex vectors are not
+// conjugated.
+func (v VectorOf[T]) DotProd(other VectorOf[T]) T {
+	return v[0]*other[0] + v[1]*other[1] + v[2]*other[2]
+}
+
+// CrossProd returns the cross product between the current vector,
+// v, and the other vector.
+func (v VectorOf[T]) CrossProd(other VectorOf[T]) VectorOf[T] {
+	return VectorOf[T]{
+		v[1]*other[2] - v[2]*other[1],
+		v[2]*other[0] - v[0]*other[2],
+		v[0]*other[1] - v[1]*other[0],
+	}
+}
+
+// String creates formatted output for the vector and makes the
+// VectorOf part of the types that satisfy the fmt.Stringer
+// interface.
+func (v VectorOf[T]) String() string {
+	return fmt.Sprintf("(%3.2fi + %3.2fj + %3.2fk)", v[0], v[1], v[2])
+}
+
+// abs2 returns the squared magnitude of v. Reflection is only used
+// for the named types, whose dynamic type is not one of the cases.
+func abs2[T Number](v T) float64 {
+	switch x := any(v).(type) {
+	case float64:
+		return x * x
+	case float32:
+		return float64(x) * float64(x)
+	case complex128:
+		return real(x)*real(x) + imag(x)*imag(x)
+	case complex64:
+		re, im := float64(real(x)), float64(imag(x))
+		return re*re + im*im
+	}
+
+	x := reflect.ValueOf(v)
+	if x.CanComplex() {
+		c := x.Complex()
+		return real(c)*real(c) + imag(c)*imag(c)
+	}
+
+	return x.Float() * x.Float()
+}
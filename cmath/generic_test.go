@@ -0,0 +1,99 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMatrixOfFloat32(t *testing.T) {
+	a, _ := StartMatrixOf[float32](2, 3, 1, 2, 3, 4, 5, 6)
+	b, _ := StartMatrixOf[float32](3, 2, 7, 8, 9, 10, 11, 12)
+
+	prod, err := a.Product(b)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans, _ := StartMatrixOf[float32](2, 2, 58, 64, 139, 154)
+	if !prod.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, prod)
+	}
+
+	sum, _ := a.Add(b.Transpose())
+	ans, _ = StartMatrixOf[float32](2, 3, 8, 11, 14, 12, 15, 18)
+	if !sum.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, sum)
+	}
+	if _, err := a.Sub(b); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+
+	y, _ := a.MulVec([]float32{1, 0, -1})
+	if y[0] != -2 || y[1] != -2 {
+		t.Errorf("incorrect result: expected [-2 -2], got %v.", y)
+	}
+
+	m := ToFloat64(a)
+	det, _ := m.Product(m.Transpose())
+	if d, _ := det.Det(); math.Abs(d-54) > 1e-9 {
+		t.Errorf("incorrect result: expected det 54, got %v.", d)
+	}
+	if back := FromFloat64[float32](m); !back.IsEqual(a) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", a, back)
+	}
+}
+
+func TestMatrixOfComplex(t *testing.T) {
+	a, _ := StartMatrixOf(2, 2, 1+1i, 2, 0, 1i)
+	scaled := a.ScalarProduct(2i)
+	ans, _ := StartMatrixOf(2, 2, -2+2i, 4i, 0, -2)
+	if !scaled.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, scaled)
+	}
+
+	// Matrix and CMatrix share the storage of MatrixOf.
+	c := CMatrix(a)
+	if h := c.ConjTranspose(); h.at(0, 0) != 1-1i || h.at(1, 0) != 2 {
+		t.Errorf("incorrect result: expected conjugate transpose, got\n%v.", h)
+	}
+	m, _ := StartMatrix(1, 2, 3, 4)
+	if g := MatrixOf[float64](m); g.at(0, 1) != 4 {
+		t.Errorf("incorrect result: expected 4, got %v.", g.at(0, 1))
+	}
+}
+
+func TestVectorOf(t *testing.T) {
+	v := VectorOf[float32]{1, 2, 2}
+	if v.Norm() != 3 {
+		t.Errorf("incorrect result: expected 3, got %v.", v.Norm())
+	}
+	w := VectorOf[float32]{0, 1, 0}
+	if c := v.CrossProd(w); c != (VectorOf[float32]{-2, 0, 1}) {
+		t.Errorf("incorrect result: expected (-2, 0, 1), got %v.", c)
+	}
+
+	p := VectorOf[complex128]{3i, 4, 0}
+	if p.Norm() != 5 {
+		t.Errorf("incorrect result: expected 5, got %v.", p.Norm())
+	}
+	if d := p.DotProd(p); d != 7 {
+		t.Errorf("incorrect result: expected 7, got %v.", d)
+	}
+}
+
+func TestVectorOfNamedType(t *testing.T) {
+	type meters float64
+	v := VectorOf[meters]{3, 4, 0}
+	if v.Norm() != 5 {
+		t.Errorf("incorrect result: expected 5, got %v.", v.Norm())
+	}
+}
+
+func BenchmarkVectorNorm(b *testing.B) {
+	v := Vector{1, 2, 3}
+	s := 0.
+	for i := 0; i < b.N; i++ {
+		s += v.Norm()
+	}
+	_ = s
+}
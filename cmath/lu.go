@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+LU holds the LU decomposition with partial pivoting of a square
+matrix A, so that P*A = L*U, where P is the permutation recorded
+in the row pivots, L is a unit lower triangular matrix and U is
+an upper triangular matrix.
+
+The factorization is computed once by Matrix.LU and can then be
+reused to solve the system for as many right-hand sides as
+necessary, to compute the determinant and the inverse matrix.
+*/
+type LU struct {
+	lu   [][]float64
+	piv  []int
+	sign float64
+	tol  float64
+	n    int
+}
+
+// LU returns the LU decomposition with partial pivoting of the
+// current matrix, m. A NotSquareError is returned if m is not a
+// square matrix. A singular matrix is not an error at this point,
+// it will only be reported by the methods that depend on the
+// inverse of m.
+func (m Matrix) LU() (*LU, error) {
+	if m.rows != m.cols {
+		return nil, NotSquareError{m.rows, m.cols}
+	}
+
+	n := m.rows
+	f := &LU{
+		lu:   m.copyElems(),
+		piv:  make([]int, n),
+		sign: 1.,
+		n:    n,
+	}
+
+	// Pivots smaller than tol are considered zeros. The limit is
+	// relative to the largest element of the matrix, so that the
+	// test does not depend on the scale of m.
+	amax := 0.
+	for r := 0; r < n; r++ {
+		f.piv[r] = r
+		for c := 0; c < n; c++ {
+			amax = math.Max(amax, math.Abs(f.lu[r][c]))
+		}
+	}
+	f.tol = float64(n) * amax * epsilon
+
+	a := f.lu
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i][k]) > math.Abs(a[p][k]) {
+				p = i
+			}
+		}
+		if p != k {
+			a[p], a[k] = a[k], a[p]
+			f.piv[p], f.piv[k] = f.piv[k], f.piv[p]
+			f.sign = -f.sign
+		}
+
+		if a[k][k] == 0. {
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			a[i][k] /= a[k][k]
+			for j := k + 1; j < n; j++ {
+				a[i][j] -= a[i][k] * a[k][j]
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// epsilon is the machine epsilon for float64, the difference
+// between 1 and the next representable number.
+const epsilon = 2.220446049250313e-16
+
+// IsSingular returns true if one of the pivots of U is zero, or
+// too small compared to the elements of the original matrix.
+func (f *LU) IsSingular() bool {
+	for k := 0; k < f.n; k++ {
+		if math.Abs(f.lu[k][k]) <= f.tol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// L returns the unit lower triangular factor.
+func (f *LU) L() Matrix {
+	l := StartZerosMatrix(f.n, f.n)
+	for r := 0; r < f.n; r++ {
+		for c := 0; c < r; c++ {
+			l.elems[r*l.stride+c] = f.lu[r][c]
+		}
+		l.elems[r*l.stride+r] = 1.
+	}
+
+	return l
+}
+
+// U returns the upper triangular factor.
+func (f *LU) U() Matrix {
+	u := StartZerosMatrix(f.n, f.n)
+	for r := 0; r < f.n; r++ {
+		for c := r; c < f.n; c++ {
+			u.elems[r*u.stride+c] = f.lu[r][c]
+		}
+	}
+
+	return u
+}
+
+// Pivot returns the row permutation of the decomposition: row r
+// of P*A is the row Pivot()[r] of A.
+func (f *LU) Pivot() []int {
+	p := make([]int, f.n)
+	copy(p, f.piv)
+
+	return p
+}
+
+// Det returns the determinant of the decomposed matrix.
+func (f *LU) Det() float64 {
+	det := f.sign
+	for k := 0; k < f.n; k++ {
+		det *= f.lu[k][k]
+	}
+
+	return det
+}
+
+// Solve returns the solution x of the system A*x = b. An error
+// is generated if the length of b does not match the order of A,
+// and ErrSingular is returned if A is singular.
+func (f *LU) Solve(b []float64) ([]float64, error) {
+	if len(b) != f.n {
+		return nil, fmt.Errorf("b has %d elements, expected %d", len(b), f.n)
+	}
+	if f.IsSingular() {
+		return nil, ErrSingular
+	}
+
+	x := make([]float64, f.n)
+	for r := 0; r < f.n; r++ {
+		x[r] = b[f.piv[r]]
+	}
+	f.solveInPlace(x)
+
+	return x, nil
+}
+
+// SolveMatrix returns the solution X of the system A*X = B,
+// solving A for each column of B. An error is generated if the
+// number of rows of B does not match the order of A, and
+// ErrSingular is returned if A is singular.
+func (f *LU) SolveMatrix(b Matrix) (Matrix, error) {
+	if b.rows != f.n {
+		return Matrix{}, fmt.Errorf("B has %d rows, expected %d", b.rows, f.n)
+	}
+	if f.IsSingular() {
+		return Matrix{}, ErrSingular
+	}
+
+	x := StartZerosMatrix(b.rows, b.cols)
+	col := make([]float64, f.n)
+	for c := 0; c < b.cols; c++ {
+		for r := 0; r < f.n; r++ {
+			col[r] = b.at(f.piv[r], c)
+		}
+		f.solveInPlace(col)
+		for r := 0; r < f.n; r++ {
+			x.elems[r*x.stride+c] = col[r]
+		}
+	}
+
+	return x, nil
+}
+
+// Inverse returns the inverse of the decomposed matrix. ErrSingular
+// is returned if the matrix is singular.
+func (f *LU) Inverse() (Matrix, error) {
+	return f.SolveMatrix(StartIdentityMatrix(f.n))
+}
+
+// solveInPlace overwrites the permuted right-hand side x with
+// the solution of L*U*x = x, by forward and back substitution.
+func (f *LU) solveInPlace(x []float64) {
+	a := f.lu
+	for r := 1; r < f.n; r++ {
+		for c := 0; c < r; c++ {
+			x[r] -= a[r][c] * x[c]
+		}
+	}
+	for r := f.n - 1; r >= 0; r-- {
+		for c := r + 1; c < f.n; c++ {
+			x[r] -= a[r][c] * x[c]
+		}
+		x[r] /= a[r][r]
+	}
+}
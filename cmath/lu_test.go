@@ -0,0 +1,109 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLU(t *testing.T) {
+	m, _ := StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+	if _, err := m.LU(); err == nil {
+		t.Error("incorrect result: expected error, matrix is not square.")
+	}
+
+	m, _ = StartMatrix(3, 3, 1, 9, 5, 3, 7, 8, 10, 4, 2)
+	lu, err := m.LU()
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+
+	// P*A must be equal to L*U.
+	pa := StartZerosMatrix(3, 3)
+	for r, p := range lu.Pivot() {
+		for c := 0; c < 3; c++ {
+			pa.SetElement(r, c, m.at(p, c))
+		}
+	}
+	prod, _ := lu.L().Product(lu.U())
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			if math.Abs(prod.at(r, c)-pa.at(r, c)) > 1e-12 {
+				t.Fatalf("incorrect result: expected L*U = \n%v, got\n%v.", pa, prod)
+			}
+		}
+	}
+
+	if d := lu.Det(); math.Abs(d-358.) > 1e-12 {
+		t.Errorf("incorrect result: expected d = %1.2f, got %1.2f.", 358., d)
+	}
+}
+
+func TestLUSolve(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 2, 1, -1, -3, -1, 2, -2, 1, 2)
+	lu, _ := m.LU()
+
+	if _, err := lu.Solve([]float64{1, 2}); err == nil {
+		t.Error("incorrect result: expected error, b has wrong size.")
+	}
+
+	x, err := lu.Solve([]float64{8, -11, -3})
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	ans := []float64{2, 3, -1}
+	for i := range ans {
+		if math.Abs(x[i]-ans[i]) > 1e-12 {
+			t.Errorf("incorrect result: expected %v, got %v.", ans, x)
+			break
+		}
+	}
+
+	b, _ := StartMatrix(3, 2, 8, 1, -11, -1, -3, 1)
+	xm, err := lu.SolveMatrix(b)
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	ax, _ := m.Product(xm)
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 2; c++ {
+			if math.Abs(ax.at(r, c)-b.at(r, c)) > 1e-12 {
+				t.Fatalf("incorrect result: expected A*X = \n%v, got\n%v.", b, ax)
+			}
+		}
+	}
+}
+
+func TestLUInverse(t *testing.T) {
+	m, _ := StartMatrix(2, 2, 4, 7, 2, 6)
+	lu, _ := m.LU()
+	inv, err := lu.Inverse()
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	ans, _ := StartMatrix(2, 2, 0.6, -0.7, -0.2, 0.4)
+	for r := 0; r < 2; r++ {
+		for c := 0; c < 2; c++ {
+			if math.Abs(inv.at(r, c)-ans.at(r, c)) > 1e-12 {
+				t.Fatalf("incorrect result: expected \n%v, got\n%v.", ans, inv)
+			}
+		}
+	}
+}
+
+func TestLUSingular(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	lu, _ := m.LU()
+	if !lu.IsSingular() {
+		t.Error("incorrect result: expected singular matrix.")
+	}
+	if _, err := lu.Solve([]float64{1, 2, 3}); !errors.Is(err, ErrSingular) {
+		t.Errorf("incorrect result: expected ErrSingular, got %v.", err)
+	}
+	if _, err := lu.Inverse(); !errors.Is(err, ErrSingular) {
+		t.Errorf("incorrect result: expected ErrSingular, got %v.", err)
+	}
+	if d := lu.Det(); math.Abs(d) > 1e-12 {
+		t.Errorf("incorrect result: expected d = 0, got %v.", d)
+	}
+}
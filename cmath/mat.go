@@ -0,0 +1,184 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+)
+
+/*
+Mat is the interface satisfied by all the matrix types of cmath:
+the dense Matrix and the structured Diagonal, UpperTriangular,
+LowerTriangular, Symmetric and Banded matrices.
+
+At returns the element [r][c] and panics if it does not exist, as
+an index out of range of a slice. Set changes the element [r][c]
+and returns an error if it does not exist or if the value breaks
+the structure of the matrix, such as a nonzero element out of the
+diagonal of a Diagonal. T returns the transpose.
+
+The functions Det and Product accept any Mat and use the structure
+of their arguments to save work.
+*/
+type Mat interface {
+	Dims() (int, int)
+	At(r, c int) float64
+	Set(r, c int, v float64) error
+	T() Mat
+}
+
+// At returns the element [r][c] of the current matrix, m. It panics
+// if the element does not exist.
+func (m Matrix) At(r, c int) float64 {
+	if r < 0 || r >= m.rows || c < 0 || c >= m.cols {
+		panic(fmt.Sprintf("there is not element [%d][%d] in this matrix", r, c))
+	}
+
+	return m.elems[r*m.stride+c]
+}
+
+// Set changes the element [r][c] of the current matrix, m, as
+// SetElement does.
+func (m Matrix) Set(r, c int, v float64) error {
+	return m.SetElement(r, c, v)
+}
+
+// T returns the transpose of the current matrix, m.
+func (m Matrix) T() Mat {
+	return m.Transpose()
+}
+
+// ToDense returns a dense Matrix with the elements of a. If a is a
+// Matrix it is returned without copying.
+func ToDense(a Mat) Matrix {
+	switch a := a.(type) {
+	case Matrix:
+		return a
+	case *Matrix:
+		return *a
+	}
+
+	r, c := a.Dims()
+	m := StartZerosMatrix(r, c)
+	for i := 0; i < r; i++ {
+		row := m.row(i)
+		for j := range row {
+			row[j] = a.At(i, j)
+		}
+	}
+
+	return m
+}
+
+// Det returns the determinant of the square matrix a. Diagonal and
+// triangular matrices are resolved in O(n), by the product of the
+// diagonal, the others by the LU decomposition. A NotSquareError is
+// returned if a is not square.
+func Det(a Mat) (float64, error) {
+	switch a := a.(type) {
+	case Diagonal:
+		return a.Det(), nil
+	case UpperTriangular:
+		return a.Det(), nil
+	case LowerTriangular:
+		return a.Det(), nil
+	case Banded:
+		return a.Det()
+	}
+
+	return ToDense(a).Det()
+}
+
+// Product returns the product a*b. When one of the factors is a
+// Diagonal, a triangular or a Banded matrix, only its nonzero
+// elements are visited. An error wrapping ErrDimension is generated
+// if the number of columns of a is different from the number of
+// rows of b.
+func Product(a, b Mat) (Matrix, error) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ac != br {
+		return Matrix{}, fmt.Errorf("in A*B the A.columns must be equal to B.rows: %w", ErrDimension)
+	}
+
+	// A diagonal factor scales the rows of b or the columns of a.
+	if d, ok := a.(Diagonal); ok {
+		result := copyDense(b)
+		for r := 0; r < br; r++ {
+			row := result.row(r)
+			for c := range row {
+				row[c] *= d.d[r]
+			}
+		}
+		return result, nil
+	}
+	if d, ok := b.(Diagonal); ok {
+		result := copyDense(a)
+		for r := 0; r < ar; r++ {
+			row := result.row(r)
+			for c := range row {
+				row[c] *= d.d[c]
+			}
+		}
+		return result, nil
+	}
+
+	// Structured left factors only visit the columns of their band.
+	if lo, hi, ok := bandOf(a); ok {
+		dense := ToDense(b)
+		result := StartZerosMatrix(ar, bc)
+		for r := 0; r < ar; r++ {
+			row := result.row(r)
+			for k := max(0, r-lo); k <= min(ac-1, r+hi); k++ {
+				v := a.At(r, k)
+				for c, bkc := range dense.row(k) {
+					row[c] += v * bkc
+				}
+			}
+		}
+		return result, nil
+	}
+
+	return ToDense(a).Product(ToDense(b))
+}
+
+// bandOf returns the number of sub-diagonals, lo, and of
+// super-diagonals, hi, that can have nonzero elements in the
+// structured matrix a. ok is false for the matrices without a band
+// structure.
+func bandOf(a Mat) (lo, hi int, ok bool) {
+	r, c := a.Dims()
+	switch a := a.(type) {
+	case UpperTriangular:
+		return 0, c, true
+	case LowerTriangular:
+		return r, 0, true
+	case Banded:
+		return a.kl, a.ku, true
+	}
+
+	return 0, 0, false
+}
+
+// copyDense returns a dense copy of a, which can be changed without
+// affecting a.
+func copyDense(a Mat) Matrix {
+	if p, ok := a.(*Matrix); ok {
+		a = *p
+	}
+	m, ok := a.(Matrix)
+	if !ok {
+		return ToDense(a)
+	}
+
+	result := StartZerosMatrix(m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		copy(result.row(r), m.row(r))
+	}
+
+	return result
+}
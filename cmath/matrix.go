@@ -22,77 +22,123 @@ manipulation rules.
 
 Array elements can be initialized by the StratMatrix and
 ZerosMatrix methods.
+
+The elements are stored row by row in a single slice, where the
+element [r][c] is at position r*stride+c. The stride is equal to
+the number of columns, except for the views returned by Slice,
+which share the elements of a larger matrix.
+
+Matrix is defined as the float64 MatrixOf, so the operations that
+do not depend on the element type are shared with it.
 */
-type Matrix struct {
-	elems [][]float64
-	rows  int
-	cols  int
-}
+type Matrix MatrixOf[float64]
 
 // GetElement returns the matrix elements of the row r
 // and column c. An error is generated if the requested
 // element exceeds the matrix size.
 func (m Matrix) GetElement(r, c int) (float64, error) {
-	if r >= 0 && r < m.rows && c >= 0 && c < m.cols {
-		return m.elems[r][c], nil
-	}
-	return 0., fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+	return MatrixOf[float64](m).GetElement(r, c)
 }
 
 // SetElement changes the matrix elements of row r and column
 // c with value v. An error is generated if the requested
 // element exceeds the matrix size.
 func (m *Matrix) SetElement(r, c int, v float64) error {
-	if r >= 0 && r < m.rows && c >= 0 && c < m.cols {
-		m.elems[r][c] = v
-		return nil
-	}
-	return fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+	return (*MatrixOf[float64])(m).SetElement(r, c, v)
 }
 
 // StartMatrix starts a matrix with r rows and c columns with the
-// elements passed by list e. An error is generated if the number
-// of elements does not match the product r*c.
+// elements passed by list e. An error is generated if r or c is
+// negative or if the number of elements does not match the product
+// r*c.
 func StartMatrix(r int, c int, e ...float64) (Matrix, error) {
-	if r*c != len(e) {
-		return Matrix{}, fmt.Errorf("rows (%d) x columns (%d) is different from the number of matrix elements (%d)", r, c, len(e))
-	}
+	m, err := StartMatrixOf(r, c, e...)
 
-	m := newMatrix(r, c)
-
-	for i := 0; i < r; i++ {
-		row := []float64{}
-		for j := 0; j < c; j++ {
-			row = append(row, e[i*c+j])
-		}
-		m.elems = append(m.elems, row)
-	}
-
-	return m, nil
+	return Matrix(m), err
 }
 
 // StartZerosMatrix starts a matrix with zero elements with r rows
-// and c columns.
+// and c columns. r and c must not be negative, otherwise it panics.
 func StartZerosMatrix(r, c int) Matrix {
-	m := newMatrix(r, c)
+	return newMatrix(r, c)
+}
 
-	for i := 0; i < r; i++ {
-		row := make([]float64, c)
-		m.elems = append(m.elems, row)
+// checkDims returns an error if the dimensions r x c are negative.
+func checkDims(r, c int) error {
+	if r < 0 || c < 0 {
+		return fmt.Errorf("invalid matrix dimensions %dx%d: rows and columns must not be negative", r, c)
 	}
 
-	return m
+	return nil
 }
 
 // newMatrix return a new zeros elements Matrix with r rows
-// and c cols.
+// and c cols, allocated in a single slice.
 func newMatrix(r, c int) Matrix {
-	var m Matrix
-	m.elems = [][]float64{}
-	m.rows = r
-	m.cols = c
+	return Matrix(StartZerosMatrixOf[float64](r, c))
+}
 
-	return m
+// Dims returns the number of rows and columns of the current
+// matrix, m.
+func (m Matrix) Dims() (int, int) {
+	return m.rows, m.cols
+}
+
+// MulVec returns the product of the current matrix, m, by the
+// column vector x. An error is generated if the length of x is
+// different from the number of columns of m.
+func (m Matrix) MulVec(x []float64) ([]float64, error) {
+	y := make([]float64, m.rows)
+	if err := MulVecTo(y, m, x); err != nil {
+		return nil, err
+	}
+
+	return y, nil
+}
+
+// Slice returns a view of the rows r0 to r1-1 and columns c0 to
+// c1-1 of the current matrix, m. The view shares the elements of
+// m, so changes in one of them are seen by the other. An error is
+// generated if the limits are outside the matrix.
+func (m Matrix) Slice(r0, r1, c0, c1 int) (Matrix, error) {
+	if r0 < 0 || r1 > m.rows || r0 > r1 || c0 < 0 || c1 > m.cols || c0 > c1 {
+		return Matrix{}, fmt.Errorf("slice [%d:%d][%d:%d] is outside the %dx%d matrix", r0, r1, c0, c1, m.rows, m.cols)
+	}
+
+	view := Matrix{
+		rows:   r1 - r0,
+		cols:   c1 - c0,
+		stride: m.stride,
+	}
+	if view.rows > 0 && view.cols > 0 {
+		// The capacity of the view must reach the end of the array
+		// of m, as origin needs: do not limit it with a full slice
+		// expression.
+		view.elems = m.elems[r0*m.stride+c0 : (r1-1)*m.stride+c1]
+	}
+
+	return view, nil
+}
+
+// RowView returns the row r of the current matrix, m, as a slice
+// that shares the elements of m. An error is generated if the row
+// does not exist.
+func (m Matrix) RowView(r int) ([]float64, error) {
+	if r < 0 || r >= m.rows {
+		return nil, fmt.Errorf("there is not row %d in this matrix", r)
+	}
+
+	return m.row(r), nil
+}
+
+// row returns the row r of m, sharing its elements.
+func (m Matrix) row(r int) []float64 {
+	return MatrixOf[float64](m).row(r)
+}
+
+// at returns the element [r][c] of m, without bounds checking.
+func (m Matrix) at(r, c int) float64 {
+	return m.elems[r*m.stride+c]
 }
 
 // SetMatrix reset current matrix elements with list e. An
@@ -105,7 +151,7 @@ func (m *Matrix) SetMatrix(e ...float64) error {
 
 	for r := 0; r < m.rows; r++ {
 		for c := 0; c < m.cols; c++ {
-			m.elems[r][c] = e[r*m.cols+c]
+			m.elems[r*m.stride+c] = e[r*m.cols+c]
 		}
 	}
 
@@ -116,27 +162,19 @@ func (m *Matrix) SetMatrix(e ...float64) error {
 func (m *Matrix) SetZeros() {
 	for r := 0; r < m.rows; r++ {
 		for c := 0; c < m.cols; c++ {
-			m.elems[r][c] = 0.
+			m.elems[r*m.stride+c] = 0.
 		}
 	}
 }
 
 // Product returns the product between the current matrix, m, and
 // the matrix other. An error is generated if the number of columns of
-// m is different from the number of rows of w.
+// m is different from the number of rows of w. Large products are
+// computed by blocks, in parallel, with up to MaxWorkers goroutines.
 func (m Matrix) Product(other Matrix) (Matrix, error) {
-	if m.cols != other.rows {
-		return Matrix{}, fmt.Errorf("in A*B the A.columns must be equal to B.rows")
-	}
-
 	result := StartZerosMatrix(m.rows, other.cols)
-
-	for r := 0; r < result.rows; r++ {
-		for c := 0; c < result.cols; c++ {
-			for k := 0; k < result.rows; k++ {
-				result.elems[r][c] += m.elems[r][k] * other.elems[k][c]
-			}
-		}
+	if err := ProductTo(&result, m, other); err != nil {
+		return Matrix{}, err
 	}
 
 	return result, nil
@@ -145,31 +183,14 @@ func (m Matrix) Product(other Matrix) (Matrix, error) {
 // IsEqual compares the current matrix, m, with the matrix other
 // and returns true if they are equal
 func (m Matrix) IsEqual(other Matrix) bool {
-	if m.cols != other.cols || m.rows != other.rows {
-		return false
-	}
-
-	for r := 0; r < m.rows; r++ {
-		for c := 0; c < m.cols; c++ {
-			if m.elems[r][c] != other.elems[r][c] {
-				return false
-			}
-		}
-	}
-
-	return true
+	return MatrixOf[float64](m).IsEqual(MatrixOf[float64](other))
 }
 
 // RealProduct retorna uma matriz produto da Matrizes
 // corrente, m, por uma constante real c.
 func (m Matrix) RealProduct(real float64) Matrix {
 	result := StartZerosMatrix(m.rows, m.cols)
-
-	for r := 0; r < m.rows; r++ {
-		for c := 0; c < m.cols; c++ {
-			result.elems[r][c] = m.elems[r][c] * real
-		}
-	}
+	RealProductTo(&result, m, real)
 
 	return result
 }
@@ -178,15 +199,9 @@ func (m Matrix) RealProduct(real float64) Matrix {
 // other matrix. An error is generated if the arrays have
 // different sizes.
 func (m Matrix) Add(other Matrix) (Matrix, error) {
-	if m.rows != other.rows || m.cols != other.cols {
-		return Matrix{}, fmt.Errorf("it is not possible to add matrices of different sizes")
-	}
 	result := StartZerosMatrix(m.rows, m.cols)
-
-	for r := 0; r < m.rows; r++ {
-		for c := 0; c < m.cols; c++ {
-			result.elems[r][c] = m.elems[r][c] + other.elems[r][c]
-		}
+	if err := AddTo(&result, m, other); err != nil {
+		return Matrix{}, err
 	}
 
 	return result, nil
@@ -196,15 +211,9 @@ func (m Matrix) Add(other Matrix) (Matrix, error) {
 // other matrix. An error is generated if the arrays have different
 // sizes.
 func (m Matrix) Sub(other Matrix) (Matrix, error) {
-	if m.rows != other.rows || m.cols != other.cols {
-		return Matrix{}, fmt.Errorf("it is not possible to subtract matrices of different sizes")
-	}
 	result := StartZerosMatrix(m.rows, m.cols)
-
-	for r := 0; r < m.rows; r++ {
-		for c := 0; c < m.cols; c++ {
-			result.elems[r][c] = m.elems[r][c] - other.elems[r][c]
-		}
+	if err := SubTo(&result, m, other); err != nil {
+		return Matrix{}, err
 	}
 
 	return result, nil
@@ -217,8 +226,7 @@ func (m Matrix) Det2() (float64, error) {
 		return 0., fmt.Errorf("is not a 2x2 matrix")
 	}
 
-	e := m.elems
-	det := e[0][0]*e[1][1] - e[0][1]*e[1][0]
+	det := m.at(0, 0)*m.at(1, 1) - m.at(0, 1)*m.at(1, 0)
 
 	return det, nil
 }
@@ -229,28 +237,155 @@ func (m Matrix) Det3() (float64, error) {
 	if m.rows != 3 || m.cols != 3 {
 		return 0., fmt.Errorf("is not a 3x3 matrix")
 	}
-	e := m.elems
-	det := e[0][0]*(e[1][1]*e[2][2]-e[1][2]*e[2][1]) -
-		e[0][1]*(e[1][0]*e[2][2]-e[1][2]*e[2][0]) +
-		e[0][2]*(e[1][0]*e[2][1]-e[1][1]*e[2][0])
+	e := m.at
+	det := e(0, 0)*(e(1, 1)*e(2, 2)-e(1, 2)*e(2, 1)) -
+		e(0, 1)*(e(1, 0)*e(2, 2)-e(1, 2)*e(2, 0)) +
+		e(0, 2)*(e(1, 0)*e(2, 1)-e(1, 1)*e(2, 0))
 
 	return det, nil
 }
 
-// String creates formatted output for the array and makes the
-// Matrix part of the types that satisfy the fmt.Stringer interface.
-func (m Matrix) String() string {
-	if len(m.elems) > 0 {
-		str := "["
-		for r := 0; r < m.rows; r++ {
-			for c := 0; c < m.cols; c++ {
-				str += fmt.Sprintf("%+3.2f ", m.elems[r][c])
+// Det returns the determinant of a square matrix of any order.
+// The 2x2 and 3x3 cases are resolved by Det2 and Det3, larger
+// matrices are reduced to an upper triangular form by Gaussian
+// elimination with partial pivoting (LU decomposition), and the
+// determinant is the product of the pivots. A NotSquareError is
+// returned if the matrix is not square.
+func (m Matrix) Det() (float64, error) {
+	if m.rows != m.cols {
+		return 0., NotSquareError{m.rows, m.cols}
+	}
+
+	switch m.rows {
+	case 0:
+		return 1., nil
+	case 1:
+		return m.elems[0], nil
+	case 2:
+		return m.Det2()
+	case 3:
+		return m.Det3()
+	}
+
+	lu, err := m.LU()
+	if err != nil {
+		return 0., err
+	}
+
+	return lu.Det(), nil
+}
+
+// Transpose returns the transpose of the current matrix, m.
+func (m Matrix) Transpose() Matrix {
+	return Matrix(MatrixOf[float64](m).Transpose())
+}
+
+// Minor returns the minor of the element in row r and column c,
+// the determinant of the matrix obtained by removing row r and
+// column c from m. An error is generated if m is not square or
+// if the element does not exist.
+func (m Matrix) Minor(r, c int) (float64, error) {
+	if m.rows != m.cols {
+		return 0., NotSquareError{m.rows, m.cols}
+	}
+	if r < 0 || r >= m.rows || c < 0 || c >= m.cols {
+		return 0., fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+	}
+
+	return m.removeRowCol(r, c).Det()
+}
+
+// Cofactor returns the cofactor of the element in row r and
+// column c, (-1)^(r+c) times the minor of the element. An error
+// is generated if m is not square or if the element does not
+// exist.
+func (m Matrix) Cofactor(r, c int) (float64, error) {
+	minor, err := m.Minor(r, c)
+	if err != nil {
+		return 0., err
+	}
+	if (r+c)%2 != 0 {
+		return -minor, nil
+	}
+
+	return minor, nil
+}
+
+// Adjugate returns the adjugate (classical adjoint) of the current
+// matrix, m, the transpose of its cofactor matrix. A NotSquareError
+// is returned if m is not square.
+func (m Matrix) Adjugate() (Matrix, error) {
+	if m.rows != m.cols {
+		return Matrix{}, NotSquareError{m.rows, m.cols}
+	}
+
+	result := StartZerosMatrix(m.rows, m.cols)
+	if m.rows == 1 {
+		result.elems[0] = 1.
+		return result, nil
+	}
+
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			cof, err := m.Cofactor(r, c)
+			if err != nil {
+				return Matrix{}, err
+			}
+			result.elems[c*result.stride+r] = cof
+		}
+	}
+
+	return result, nil
+}
+
+// Inverse returns the inverse of the current matrix, m, computed
+// by LU decomposition. A NotSquareError is returned if m is not
+// square and ErrSingular if m is singular.
+func (m Matrix) Inverse() (Matrix, error) {
+	lu, err := m.LU()
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	return lu.Inverse()
+}
+
+// removeRowCol returns a copy of m without the row r and the
+// column c.
+func (m Matrix) removeRowCol(r, c int) Matrix {
+	result := StartZerosMatrix(m.rows-1, m.cols-1)
+
+	for i, ri := 0, 0; i < m.rows; i++ {
+		if i == r {
+			continue
+		}
+		for j, cj := 0, 0; j < m.cols; j++ {
+			if j == c {
+				continue
 			}
-			str = str[:len(str)-1] + "]\n["
+			result.elems[ri*result.stride+cj] = m.elems[i*m.stride+j]
+			cj++
 		}
-		str = str[:len(str)-1]
+		ri++
+	}
+
+	return result
+}
 
-		return str
+// copyElems returns a copy of the matrix elements that can be
+// changed without affecting the matrix.
+func (m Matrix) copyElems() [][]float64 {
+	a := make([][]float64, m.rows)
+	for r := 0; r < m.rows; r++ {
+		a[r] = make([]float64, m.cols)
+		copy(a[r], m.row(r))
 	}
-	return "[]\n"
+
+	return a
+}
+
+// String creates formatted output for the array and makes the
+// Matrix part of the types that satisfy the fmt.Stringer interface.
+func (m Matrix) String() string {
+	return MatrixOf[float64](m).String()
 }
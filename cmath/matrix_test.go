@@ -1,6 +1,7 @@
 package cmath
 
 import (
+	"errors"
 	"math"
 	"testing"
 )
@@ -50,7 +51,7 @@ func TestSetElement(t *testing.T) {
 		t.Error("incorrect result: expected error nil.")
 	}
 
-	if m.elems[1][0] != 0. || m.elems[2][1] != 0. {
+	if m.at(1, 0) != 0. || m.at(2, 1) != 0. {
 		t.Error("incorrect result: expected v10, v21 = 0., 0.")
 	}
 }
@@ -61,6 +62,11 @@ func TestStartMatrix(t *testing.T) {
 		t.Errorf("incorrect result: expected error c*r, got nil")
 	}
 
+	_, err = StartMatrix(-1, -2, 1, 2)
+	if err == nil {
+		t.Error("incorrect result: expected error, negative dimensions.")
+	}
+
 	m, err := StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
 	if err != nil && m.cols == 3 && m.rows == 2 {
 		t.Error("incorrect result: expected error nil, got error or matrix 3x2.")
@@ -94,6 +100,65 @@ func TestStartZerosMatrix(t *testing.T) {
 		t.Error("incorrect result: expected sum == 0.")
 	}
 	t.Log("All elements are zeros.")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("incorrect result: expected panic, negative dimensions.")
+		}
+	}()
+	StartZerosMatrix(-1, 2)
+}
+
+func TestSlice(t *testing.T) {
+	m, _ := StartMatrix(3, 4, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)
+	if _, err := m.Slice(1, 4, 0, 2); err == nil {
+		t.Error("incorrect result: expected error, rows outside the matrix.")
+	}
+
+	view, err := m.Slice(1, 3, 1, 3)
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	ans, _ := StartMatrix(2, 2, 6, 7, 10, 11)
+	if !ans.IsEqual(view) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, view)
+	}
+
+	// Changes in the view are seen by the matrix and vice versa.
+	view.SetElement(0, 0, -6)
+	if v, _ := m.GetElement(1, 1); v != -6. {
+		t.Errorf("incorrect result: expected m[1][1] = -6, got %v.", v)
+	}
+	m.SetElement(2, 2, -11)
+	if v, _ := view.GetElement(1, 1); v != -11. {
+		t.Errorf("incorrect result: expected view[1][1] = -11, got %v.", v)
+	}
+
+	view.SetZeros()
+	ans, _ = StartMatrix(3, 4, 1, 2, 3, 4, 5, 0, 0, 8, 9, 0, 0, 12)
+	if !ans.IsEqual(m) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, m)
+	}
+
+	if vt := view.Transpose(); vt.rows != 2 || vt.cols != 2 || vt.stride != 2 {
+		t.Errorf("incorrect result: expected compact 2x2 transpose, got %dx%d stride %d.", vt.rows, vt.cols, vt.stride)
+	}
+}
+
+func TestRowView(t *testing.T) {
+	m, _ := StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+	if _, err := m.RowView(2); err == nil {
+		t.Error("incorrect result: expected error.")
+	}
+
+	row, _ := m.RowView(1)
+	if len(row) != 3 || row[0] != 4. || row[2] != 6. {
+		t.Errorf("incorrect result: expected [4 5 6], got %v.", row)
+	}
+	row[1] = 0.
+	if v, _ := m.GetElement(1, 1); v != 0. {
+		t.Errorf("incorrect result: expected m[1][1] = 0, got %v.", v)
+	}
 }
 
 func TestSetMatrix(t *testing.T) {
@@ -113,7 +178,7 @@ func TestSetMatrix(t *testing.T) {
 	for r := 0; r < 3; r++ {
 		for c := 0; c < 2; c++ {
 			vmt := mt[r][c]
-			vm := m.elems[r][c]
+			vm := m.at(r, c)
 			if vmt != vm {
 				t.Errorf("incorrect result: expected m[%d][%d] = %f, find %f.", r, c, vmt, vm)
 			}
@@ -129,7 +194,7 @@ func TestSetZeros(t *testing.T) {
 	sum := 0.
 	for r := 0; r < 2; r++ {
 		for c := 0; c < 3; c++ {
-			sum += math.Abs(m.elems[r][c])
+			sum += math.Abs(m.at(r, c))
 		}
 	}
 	if sum != 0. {
@@ -152,6 +217,37 @@ func TestProduct(t *testing.T) {
 		t.Errorf("incorrect result: expected \n%v, got\n%v.", m2, m3)
 	}
 	t.Logf("Return expected product \n%v.", m3)
+
+	// Non-square operands: (3x2)*(2x4).
+	m1, _ = StartMatrix(3, 2, 1, 2, 3, 4, 5, 6)
+	m2, _ = StartMatrix(2, 4, 1, 0, -1, 2, 0, 1, 3, -2)
+	m4, _ := StartMatrix(3, 4, 1, 2, 5, -2, 3, 4, 9, -2, 5, 6, 13, -2)
+	m3, err := m1.Product(m2)
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	if !m4.IsEqual(m3) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", m4, m3)
+	}
+}
+
+func TestMulVec(t *testing.T) {
+	m, _ := StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+	if r, c := m.Dims(); r != 2 || c != 3 {
+		t.Errorf("incorrect result: expected 2x3, got %dx%d.", r, c)
+	}
+
+	y, err := m.MulVec([]float64{1, 0, -1})
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	if len(y) != 2 || y[0] != -2. || y[1] != -2. {
+		t.Errorf("incorrect result: expected [-2 -2], got %v.", y)
+	}
+
+	if _, err := m.MulVec([]float64{1, 0}); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
 }
 
 func TestIsEqualMatrix(t *testing.T) {
@@ -253,6 +349,118 @@ func TestDet3(t *testing.T) {
 	}
 }
 
+func TestDet(t *testing.T) {
+	m0, _ := StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+	_, err := m0.Det()
+	var nsErr NotSquareError
+	if !errors.As(err, &nsErr) {
+		t.Errorf("incorrect result: expected NotSquareError, got %v.", err)
+	}
+
+	m0, _ = StartMatrix(3, 3, 1, 9, 5, 3, 7, 8, 10, 4, 2)
+	d, err := m0.Det()
+	if err != nil {
+		t.Error("incorrect result: expected err is nil.")
+	}
+	if d != 358. {
+		t.Errorf("incorrect result: expected d = %1.2f, got %1.2f.", 358., d)
+	}
+
+	m0, _ = StartMatrix(4, 4,
+		0, -1, 0, 2,
+		-1, 2, -1, 0,
+		2, -1, 0, -1,
+		0, 0, -1, 2)
+	d, err = m0.Det()
+	if err != nil {
+		t.Error("incorrect result: expected err is nil.")
+	}
+	if math.Abs(d-(-1.)) > 1e-12 {
+		t.Errorf("incorrect result: expected d = %1.2f, got %1.2f.", -1., d)
+	}
+
+	m0, _ = StartMatrix(5, 5,
+		1, 2, 3, 4, 5,
+		2, 4, 6, 8, 10,
+		0, 1, 0, 1, 0,
+		3, 0, 1, 0, 3,
+		1, 1, 1, 1, 1)
+	d, err = m0.Det()
+	if err != nil {
+		t.Error("incorrect result: expected err is nil.")
+	}
+	if d != 0. {
+		t.Errorf("incorrect result: expected d = %1.2f, got %1.2f.", 0., d)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	m, _ := StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+	ans, _ := StartMatrix(3, 2, 1, 4, 2, 5, 3, 6)
+	if mt := m.Transpose(); !ans.IsEqual(mt) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, mt)
+	}
+}
+
+func TestMinorCofactor(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 1, 2, 3, 0, 4, 5, 1, 0, 6)
+	if _, err := m.Minor(3, 0); err == nil {
+		t.Error("incorrect result: expected error.")
+	}
+
+	minor, err := m.Minor(0, 1)
+	if err != nil {
+		t.Error("incorrect result: expected err is nil.")
+	}
+	if minor != -5. {
+		t.Errorf("incorrect result: expected minor = %1.2f, got %1.2f.", -5., minor)
+	}
+
+	cof, _ := m.Cofactor(0, 1)
+	if cof != 5. {
+		t.Errorf("incorrect result: expected cofactor = %1.2f, got %1.2f.", 5., cof)
+	}
+
+	m, _ = StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+	if _, err := m.Cofactor(0, 0); err == nil {
+		t.Error("incorrect result: expected error, matrix is not square.")
+	}
+}
+
+func TestAdjugate(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 1, 2, 3, 0, 4, 5, 1, 0, 6)
+	adj, err := m.Adjugate()
+	if err != nil {
+		t.Error("incorrect result: expected err is nil.")
+	}
+	ans, _ := StartMatrix(3, 3, 24, -12, -2, 5, 3, -5, -4, 2, 4)
+	if !ans.IsEqual(adj) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, adj)
+	}
+}
+
+func TestInverse(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 1, 2, 3, 0, 4, 5, 1, 0, 6)
+	inv, err := m.Inverse()
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	adj, _ := m.Adjugate()
+	ans := adj.RealProduct(1. / 22.)
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			if math.Abs(inv.at(r, c)-ans.at(r, c)) > 1e-12 {
+				t.Fatalf("incorrect result: expected \n%v, got\n%v.", ans, inv)
+			}
+		}
+	}
+
+	m, _ = StartMatrix(2, 2, 1, 2, 2, 4)
+	if _, err := m.Inverse(); !errors.Is(err, ErrSingular) {
+		t.Errorf("incorrect result: expected ErrSingular, got %v.", err)
+	}
+}
+
 func TestStringMatrix(t *testing.T) {
 	m, _ := StartMatrix(2, 2, 1, 2, 3, 4)
 	str := m.String()
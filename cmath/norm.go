@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"math"
+)
+
+// approxEqual reports whether a and b differ by at most absTol or
+// by at most relTol times the larger of their magnitudes. NaN is
+// not close to any value.
+func approxEqual(a, b, absTol, relTol float64) bool {
+	if a == b {
+		return true
+	}
+	diff := math.Abs(a - b)
+
+	return diff <= absTol || diff <= relTol*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// ApproxEqual compares the current matrix, m, with the matrix other
+// and returns true if they have the same size and each pair of
+// elements differ by at most absTol or by at most relTol times the
+// larger of their magnitudes. Unlike IsEqual, it does not report
+// differences due to rounding errors. NaN elements are never equal.
+func (m Matrix) ApproxEqual(other Matrix, absTol, relTol float64) bool {
+	if m.rows != other.rows || m.cols != other.cols {
+		return false
+	}
+
+	for r := 0; r < m.rows; r++ {
+		orow := other.row(r)
+		for c, v := range m.row(r) {
+			if !approxEqual(v, orow[c], absTol, relTol) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// NormFrobenius returns the Frobenius norm of the current matrix,
+// m, the square root of the sum of the squares of its elements. As
+// math.Hypot, it is +Inf if an element is infinite, even if another
+// is NaN.
+func (m Matrix) NormFrobenius() float64 {
+	// The sum is scaled by the largest magnitude, as in math.Hypot,
+	// so that the squares do not overflow.
+	scale, sum := 0., 1.
+	for r := 0; r < m.rows; r++ {
+		for _, v := range m.row(r) {
+			a := math.Abs(v)
+			switch {
+			case math.IsInf(a, 1):
+				return a
+			case a == 0.:
+			case a > scale:
+				sum = 1. + sum*(scale/a)*(scale/a)
+				scale = a
+			default:
+				sum += (a / scale) * (a / scale)
+			}
+		}
+	}
+
+	return scale * math.Sqrt(sum)
+}
+
+// Norm1 returns the 1-norm of the current matrix, m, the largest
+// sum of the magnitudes of the elements of a column.
+func (m Matrix) Norm1() float64 {
+	sums := make([]float64, m.cols)
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.row(r) {
+			sums[c] += math.Abs(v)
+		}
+	}
+
+	norm := 0.
+	for _, s := range sums {
+		norm = math.Max(norm, s)
+	}
+
+	return norm
+}
+
+// NormInf returns the infinity norm of the current matrix, m, the
+// largest sum of the magnitudes of the elements of a row.
+func (m Matrix) NormInf() float64 {
+	norm := 0.
+	for r := 0; r < m.rows; r++ {
+		s := 0.
+		for _, v := range m.row(r) {
+			s += math.Abs(v)
+		}
+		norm = math.Max(norm, s)
+	}
+
+	return norm
+}
+
+// Norm2 returns the spectral norm of the current matrix, m, its
+// largest singular value. An error is generated if the SVD does
+// not converge.
+func (m Matrix) Norm2() (float64, error) {
+	svd, err := m.SVD()
+	if err != nil {
+		return 0., err
+	}
+	if len(svd.S) == 0 {
+		return 0., nil
+	}
+
+	return svd.S[0], nil
+}
+
+// ApproxEqual returns true if each component of the current vector,
+// v, differs from the component of the other vector by at most
+// absTol or by at most relTol times the larger of their magnitudes.
+func (v VectorOf[T]) ApproxEqual(other VectorOf[T], absTol, relTol float64) bool {
+	for k := range v {
+		if v[k] == other[k] {
+			continue
+		}
+		diff := math.Sqrt(abs2(v[k] - other[k]))
+		limit := relTol * math.Max(math.Sqrt(abs2(v[k])), math.Sqrt(abs2(other[k])))
+		if !(diff <= absTol || diff <= limit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PNorm returns the p-norm of the current vector, v, the p-th root
+// of the sum of the p-th powers of the magnitudes of its components.
+// p = 1 is the sum of the magnitudes, p = 2 is Norm and p = +Inf is
+// the largest magnitude. p must be at least 1 to define a norm, a
+// smaller p returns NaN.
+func (v VectorOf[T]) PNorm(p float64) float64 {
+	var a [3]float64
+	amax := 0.
+	for k := range v {
+		a[k] = math.Sqrt(abs2(v[k]))
+		amax = math.Max(amax, a[k])
+	}
+
+	switch {
+	case p < 1 || math.IsNaN(p):
+		return math.NaN()
+	case math.IsInf(p, 1):
+		return amax
+	case p == 1:
+		return a[0] + a[1] + a[2]
+	case amax == 0.:
+		return 0.
+	}
+
+	// Scaled by the largest magnitude to avoid overflow.
+	s := 0.
+	for _, x := range a {
+		s += math.Pow(x/amax, p)
+	}
+
+	return amax * math.Pow(s, 1/p)
+}
@@ -0,0 +1,96 @@
+package cmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrixApproxEqual(t *testing.T) {
+	a := must(StartMatrix(2, 2, 1, 2, 3, 1e6))
+	b := must(StartMatrix(2, 2, 1+1e-13, 2, 3, 1e6+1e-4))
+
+	if a.IsEqual(b) {
+		t.Error("incorrect result: expected IsEqual false.")
+	}
+	if !a.ApproxEqual(b, 1e-12, 1e-9) {
+		t.Error("incorrect result: expected ApproxEqual true.")
+	}
+	if a.ApproxEqual(b, 1e-12, 0) {
+		t.Error("incorrect result: 1e-4 is larger than absTol, expected false.")
+	}
+	if !a.ApproxEqual(b, 1e-3, 0) {
+		t.Error("incorrect result: expected ApproxEqual true with absTol.")
+	}
+
+	nan := must(StartMatrix(1, 1, math.NaN()))
+	if nan.ApproxEqual(nan, 1, 1) {
+		t.Error("incorrect result: NaN must not be equal.")
+	}
+	if a.ApproxEqual(StartZerosMatrix(1, 4), 1, 1) {
+		t.Error("incorrect result: different sizes, expected false.")
+	}
+}
+
+func TestMatrixNorms(t *testing.T) {
+	a := must(StartMatrix(2, 3, 1, -2, 3, -4, 5, -6))
+
+	if n := a.Norm1(); n != 9 {
+		t.Errorf("incorrect result: expected 1-norm 9, got %v.", n)
+	}
+	if n := a.NormInf(); n != 15 {
+		t.Errorf("incorrect result: expected ∞-norm 15, got %v.", n)
+	}
+	if n := a.NormFrobenius(); math.Abs(n-math.Sqrt(91)) > 1e-14 {
+		t.Errorf("incorrect result: expected Frobenius norm √91, got %v.", n)
+	}
+	big := must(StartMatrix(1, 2, 3e200, 4e200))
+	if n := big.NormFrobenius(); math.Abs(n-5e200) > 1e186 {
+		t.Errorf("incorrect result: expected 5e200, got %v.", n)
+	}
+	inf := must(StartMatrix(1, 3, math.Inf(1), math.Inf(-1), math.NaN()))
+	if n := inf.NormFrobenius(); !math.IsInf(n, 1) {
+		t.Errorf("incorrect result: expected +Inf, got %v.", n)
+	}
+
+	// The spectral norm of [3 0; 4 5] is √45.
+	s := must(StartMatrix(2, 2, 3, 0, 4, 5))
+	n, err := s.Norm2()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	if math.Abs(n-math.Sqrt(45)) > 1e-13 {
+		t.Errorf("incorrect result: expected √45, got %v.", n)
+	}
+}
+
+func TestVectorApproxEqual(t *testing.T) {
+	v := Vector{1, 2, 3}
+	w := Vector{1, 2, 3 + 1e-14}
+	if v.IsEqual(w) || !v.ApproxEqual(w, 1e-12, 0) {
+		t.Error("incorrect result: expected approximately equal vectors.")
+	}
+	if v.ApproxEqual(Vector{1, 2, 3.1}, 1e-12, 1e-9) {
+		t.Error("incorrect result: expected different vectors.")
+	}
+}
+
+func TestVectorPNorm(t *testing.T) {
+	v := Vector{3, -4, 0}
+	tests := []struct {
+		p   float64
+		ans float64
+	}{
+		{1, 7},
+		{2, 5},
+		{3, math.Cbrt(91)},
+		{math.Inf(1), 4},
+	}
+	for _, test := range tests {
+		if n := v.PNorm(test.p); math.Abs(n-test.ans) > 1e-14 {
+			t.Errorf("incorrect result: p = %v expected %v, got %v.", test.p, test.ans, n)
+		}
+	}
+	if !math.IsNaN(v.PNorm(0.5)) {
+		t.Error("incorrect result: expected NaN for p < 1.")
+	}
+}
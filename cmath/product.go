@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// blockSize is the side of the square blocks in which the
+	// operands of a product are split, chosen so that three blocks
+	// of float64 fit in the L1/L2 caches.
+	blockSize = 64
+
+	// parallelThreshold is the number of multiply-add operations
+	// from which a product is split among several goroutines.
+	parallelThreshold = 1 << 18
+)
+
+// maxWorkers holds the number of goroutines used by the products
+// of large matrices. Zero means runtime.GOMAXPROCS(0).
+var maxWorkers atomic.Int64
+
+// SetMaxWorkers sets the number of goroutines used to multiply
+// large matrices. A value n <= 0 restores the default, which is
+// the value of runtime.GOMAXPROCS(0).
+func SetMaxWorkers(n int) {
+	if n < 0 {
+		n = 0
+	}
+	maxWorkers.Store(int64(n))
+}
+
+// MaxWorkers returns the number of goroutines used to multiply
+// large matrices.
+func MaxWorkers() int {
+	if n := int(maxWorkers.Load()); n > 0 {
+		return n
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// mulBlocked adds the product a*b to dst, which must be a
+// a.rows x b.cols matrix. The operands are traversed in square
+// blocks to reuse the cached elements, and the blocks of rows of
+// dst are spread across goroutines when the product is large.
+func mulBlocked(dst, a, b Matrix) {
+	workers := MaxWorkers()
+	blocks := (a.rows + blockSize - 1) / blockSize
+	if workers == 1 || blocks == 1 || a.rows*a.cols*b.cols < parallelThreshold {
+		mulRows(dst, a, b, 0, a.rows)
+		return
+	}
+
+	workers = min(workers, blocks)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		// Each worker receives a contiguous range of whole blocks of
+		// rows, so no two goroutines write to the same row of dst.
+		r0 := (blocks * w / workers) * blockSize
+		r1 := min((blocks*(w+1)/workers)*blockSize, a.rows)
+		go func() {
+			defer wg.Done()
+			mulRows(dst, a, b, r0, r1)
+		}()
+	}
+	wg.Wait()
+}
+
+// mulRows adds the rows r0 to r1-1 of the product a*b to the same
+// rows of dst.
+func mulRows(dst, a, b Matrix, r0, r1 int) {
+	for i0 := r0; i0 < r1; i0 += blockSize {
+		i1 := min(i0+blockSize, r1)
+		for k0 := 0; k0 < a.cols; k0 += blockSize {
+			k1 := min(k0+blockSize, a.cols)
+			for j0 := 0; j0 < b.cols; j0 += blockSize {
+				j1 := min(j0+blockSize, b.cols)
+				for i := i0; i < i1; i++ {
+					drow := dst.elems[i*dst.stride+j0 : i*dst.stride+j1]
+					for k := k0; k < k1; k++ {
+						aik := a.elems[i*a.stride+k]
+						brow := b.elems[k*b.stride+j0 : k*b.stride+j1]
+						for j, bkj := range brow {
+							drow[j] += aik * bkj
+						}
+					}
+				}
+			}
+		}
+	}
+}
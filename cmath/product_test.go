@@ -0,0 +1,76 @@
+package cmath
+
+import (
+	"math"
+	"testing"
+)
+
+// naiveProduct returns a*b computed by the definition.
+func naiveProduct(a, b Matrix) Matrix {
+	result := StartZerosMatrix(a.rows, b.cols)
+	for r := 0; r < a.rows; r++ {
+		for c := 0; c < b.cols; c++ {
+			s := 0.
+			for k := 0; k < a.cols; k++ {
+				s += a.at(r, k) * b.at(k, c)
+			}
+			result.SetElement(r, c, s)
+		}
+	}
+	return result
+}
+
+func TestProductBlocked(t *testing.T) {
+	defer SetMaxWorkers(0)
+
+	sizes := [][3]int{{1, 1, 1}, {7, 3, 5}, {65, 130, 17}, {200, 150, 190}, {131, 64, 257}}
+	for _, workers := range []int{1, 3, 0} {
+		SetMaxWorkers(workers)
+		for k, s := range sizes {
+			a := StartRandomMatrix(s[0], s[1], int64(2*k))
+			b := StartRandomMatrix(s[1], s[2], int64(2*k+1))
+			ans := naiveProduct(a, b)
+			result, err := a.Product(b)
+			if err != nil {
+				t.Fatal("incorrect result: expected err is nil.")
+			}
+			for i := range ans.elems {
+				if math.Abs(ans.elems[i]-result.elems[i]) > 1e-12 {
+					t.Fatalf("incorrect result: %dx%d * %dx%d with %d workers differs from the naive product.",
+						s[0], s[1], s[1], s[2], MaxWorkers())
+				}
+			}
+		}
+	}
+}
+
+func TestProductView(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	a, _ := m.Slice(0, 2, 1, 3)
+	b, _ := m.Slice(1, 3, 0, 1)
+	ans, _ := StartMatrix(2, 1, 2*4+3*7, 5*4+6*7)
+	if result, _ := a.Product(b); !ans.IsEqual(result) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, result)
+	}
+}
+
+func TestSetMaxWorkers(t *testing.T) {
+	defer SetMaxWorkers(0)
+	SetMaxWorkers(5)
+	if n := MaxWorkers(); n != 5 {
+		t.Errorf("incorrect result: expected 5 workers, got %d.", n)
+	}
+	SetMaxWorkers(-1)
+	if n := MaxWorkers(); n < 1 {
+		t.Errorf("incorrect result: expected default workers >= 1, got %d.", n)
+	}
+}
+
+func BenchmarkProduct(b *testing.B) {
+	m0 := StartRandomMatrix(500, 500, 1)
+	m1 := StartRandomMatrix(500, 500, 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m0.Product(m1)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+QR holds the QR decomposition of a matrix A with rows >= columns,
+A = Q*R, where Q has orthonormal columns and R is an upper
+triangular matrix.
+
+The decomposition is computed by Householder reflections, stored
+in compact form, and is the base of the least squares solver of
+overdetermined systems.
+*/
+type QR struct {
+	qr    [][]float64
+	rdiag []float64
+	rows  int
+	cols  int
+}
+
+// QR returns the QR decomposition of the current matrix, m. An
+// error is generated if m has fewer rows than columns.
+func (m Matrix) QR() (*QR, error) {
+	if m.rows < m.cols {
+		return nil, fmt.Errorf("QR decomposition needs rows (%d) >= columns (%d)", m.rows, m.cols)
+	}
+
+	f := &QR{
+		qr:    m.copyElems(),
+		rdiag: make([]float64, m.cols),
+		rows:  m.rows,
+		cols:  m.cols,
+	}
+
+	a := f.qr
+	for k := 0; k < f.cols; k++ {
+		// 2-norm of the k-th column below the diagonal, without
+		// under/overflow.
+		nrm := 0.
+		for i := k; i < f.rows; i++ {
+			nrm = math.Hypot(nrm, a[i][k])
+		}
+
+		if nrm != 0. {
+			// Form the k-th Householder vector.
+			if a[k][k] < 0 {
+				nrm = -nrm
+			}
+			for i := k; i < f.rows; i++ {
+				a[i][k] /= nrm
+			}
+			a[k][k] += 1.
+
+			// Apply the transformation to the remaining columns.
+			for j := k + 1; j < f.cols; j++ {
+				s := 0.
+				for i := k; i < f.rows; i++ {
+					s += a[i][k] * a[i][j]
+				}
+				s = -s / a[k][k]
+				for i := k; i < f.rows; i++ {
+					a[i][j] += s * a[i][k]
+				}
+			}
+		}
+		f.rdiag[k] = -nrm
+	}
+
+	return f, nil
+}
+
+// IsFullRank returns true if the columns of the decomposed matrix
+// are linearly independent, that is, if no diagonal element of R
+// is negligible compared to the largest one.
+func (f *QR) IsFullRank() bool {
+	rmax := 0.
+	for _, d := range f.rdiag {
+		rmax = math.Max(rmax, math.Abs(d))
+	}
+	tol := float64(max(f.rows, f.cols)) * rmax * epsilon
+	for _, d := range f.rdiag {
+		if math.Abs(d) <= tol {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Q returns the rows x cols matrix Q with orthonormal columns.
+func (f *QR) Q() Matrix {
+	q := StartZerosMatrix(f.rows, f.cols)
+	a := f.qr
+
+	for k := f.cols - 1; k >= 0; k-- {
+		q.elems[k*q.stride+k] = 1.
+		for j := k; j < f.cols; j++ {
+			if a[k][k] == 0. {
+				continue
+			}
+			s := 0.
+			for i := k; i < f.rows; i++ {
+				s += a[i][k] * q.at(i, j)
+			}
+			s = -s / a[k][k]
+			for i := k; i < f.rows; i++ {
+				q.elems[i*q.stride+j] += s * a[i][k]
+			}
+		}
+	}
+
+	return q
+}
+
+// R returns the cols x cols upper triangular matrix R.
+func (f *QR) R() Matrix {
+	r := StartZerosMatrix(f.cols, f.cols)
+
+	for i := 0; i < f.cols; i++ {
+		r.elems[i*r.stride+i] = f.rdiag[i]
+		for j := i + 1; j < f.cols; j++ {
+			r.elems[i*r.stride+j] = f.qr[i][j]
+		}
+	}
+
+	return r
+}
+
+// Solve returns the least squares solution x of A*x = b, the x that
+// minimizes the norm of A*x - b, and the norm of the residual. An
+// error is generated if the length of b is not equal to the number
+// of rows of A, and ErrRankDeficient is returned if A is rank
+// deficient.
+func (f *QR) Solve(b []float64) ([]float64, float64, error) {
+	if len(b) != f.rows {
+		return nil, 0., fmt.Errorf("b has %d elements, expected %d", len(b), f.rows)
+	}
+	if !f.IsFullRank() {
+		return nil, 0., ErrRankDeficient
+	}
+
+	a := f.qr
+	y := make([]float64, f.rows)
+	copy(y, b)
+
+	// Compute Qᵀ*b.
+	for k := 0; k < f.cols; k++ {
+		s := 0.
+		for i := k; i < f.rows; i++ {
+			s += a[i][k] * y[i]
+		}
+		s = -s / a[k][k]
+		for i := k; i < f.rows; i++ {
+			y[i] += s * a[i][k]
+		}
+	}
+
+	// The last rows-cols elements of Qᵀ*b are the residual.
+	res := 0.
+	for i := f.cols; i < f.rows; i++ {
+		res = math.Hypot(res, y[i])
+	}
+
+	// Solve R*x = Qᵀ*b.
+	x := y[:f.cols]
+	for k := f.cols - 1; k >= 0; k-- {
+		x[k] /= f.rdiag[k]
+		for i := 0; i < k; i++ {
+			x[i] -= x[k] * a[i][k]
+		}
+	}
+
+	return x, res, nil
+}
+
+// LeastSquares returns the least squares solution x of the
+// overdetermined system a*x = b and the norm of the residual
+// a*x - b. It allows fitting any model that is linear in its
+// parameters, with one row of a for each observation. An error is
+// generated if a has fewer rows than columns or if the length of b
+// does not match them, and ErrRankDeficient is returned if the
+// columns of a are linearly dependent.
+func LeastSquares(a Matrix, b []float64) ([]float64, float64, error) {
+	qr, err := a.QR()
+	if err != nil {
+		return nil, 0., err
+	}
+
+	return qr.Solve(b)
+}
@@ -0,0 +1,109 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestQR(t *testing.T) {
+	m, _ := StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+	if _, err := m.QR(); err == nil {
+		t.Error("incorrect result: expected error, rows < columns.")
+	}
+
+	m, _ = StartMatrix(4, 3, 12, -51, 4, 6, 167, -68, -4, 24, -41, 1, 1, 1)
+	qr, err := m.QR()
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	if !qr.IsFullRank() {
+		t.Error("incorrect result: expected full rank matrix.")
+	}
+
+	q, r := qr.Q(), qr.R()
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			e := 0.
+			for k := 0; k < 3; k++ {
+				e += q.at(i, k) * r.at(k, j)
+			}
+			if math.Abs(e-m.at(i, j)) > 1e-10 {
+				t.Fatalf("incorrect result: expected Q*R = \n%v, got %v at [%d][%d].", m, e, i, j)
+			}
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			e := 0.
+			for k := 0; k < 4; k++ {
+				e += q.at(k, i) * q.at(k, j)
+			}
+			if i == j {
+				e -= 1.
+			}
+			if math.Abs(e) > 1e-12 {
+				t.Fatalf("incorrect result: expected QᵀQ = I, got error %v at [%d][%d].", e, i, j)
+			}
+		}
+	}
+
+	for i := 1; i < 3; i++ {
+		for j := 0; j < i; j++ {
+			if r.at(i, j) != 0. {
+				t.Fatalf("incorrect result: R is not upper triangular\n%v.", r)
+			}
+		}
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// Fit y = a + b*x + c*x² to points on y = 1 - 2x + 3x².
+	xs := []float64{-2, -1, 0, 1, 2, 3}
+	a := StartZerosMatrix(len(xs), 3)
+	b := make([]float64, len(xs))
+	for i, x := range xs {
+		a.SetElement(i, 0, 1)
+		a.SetElement(i, 1, x)
+		a.SetElement(i, 2, x*x)
+		b[i] = 1 - 2*x + 3*x*x
+	}
+
+	x, res, err := LeastSquares(a, b)
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	ans := []float64{1, -2, 3}
+	for i := range ans {
+		if math.Abs(x[i]-ans[i]) > 1e-10 {
+			t.Fatalf("incorrect result: expected %v, got %v.", ans, x)
+		}
+	}
+	if res > 1e-10 {
+		t.Errorf("incorrect result: expected residual 0, got %v.", res)
+	}
+
+	// Straight line fit through non-collinear points, compared
+	// with cnumeric.LinearAdj: y = 0.6 + 1.1*x.
+	a, _ = StartMatrix(4, 2, 1, 0, 1, 1, 1, 2, 1, 3)
+	x, res, err = LeastSquares(a, []float64{1, 1, 3, 4})
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	if math.Abs(x[0]-0.6) > 1e-12 || math.Abs(x[1]-1.1) > 1e-12 {
+		t.Errorf("incorrect result: expected [0.6 1.1], got %v.", x)
+	}
+	if math.Abs(res-math.Sqrt(0.7)) > 1e-12 {
+		t.Errorf("incorrect result: expected residual %v, got %v.", math.Sqrt(0.7), res)
+	}
+
+	if _, _, err := LeastSquares(a, []float64{1, 2}); err == nil {
+		t.Error("incorrect result: expected error, b has wrong size.")
+	}
+
+	a, _ = StartMatrix(3, 2, 1, 2, 2, 4, 3, 6)
+	if _, _, err := LeastSquares(a, []float64{1, 2, 3}); !errors.Is(err, ErrRankDeficient) {
+		t.Errorf("incorrect result: expected ErrRankDeficient, got %v.", err)
+	}
+}
@@ -0,0 +1,386 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math/big"
+)
+
+/*
+RatMatrix declares a matrix of exact rational elements, backed by
+math/big.Rat. Its operations have no rounding errors, so the
+determinant, the inverse and the reduced row echelon form are the
+same fractions found by hand, such as -2/3 instead of -0.6666667.
+
+Array elements can be initialized by the StartRatMatrix and
+StartZerosRatMatrix methods, or converted from a Matrix by
+ToRatMatrix. The elements are never shared: GetElement and
+SetElement copy the values.
+*/
+type RatMatrix struct {
+	elems []*big.Rat
+	rows  int
+	cols  int
+}
+
+// StartRatMatrix starts a rational matrix with r rows and c columns
+// with the elements passed by list e, as fractions ("-2/3"),
+// integers ("4") or decimals ("0.25"). An error is generated if the
+// number of elements does not match the product r*c, if r or c is
+// negative or if an element is not a valid number.
+func StartRatMatrix(r int, c int, e ...string) (RatMatrix, error) {
+	if err := checkDims(r, c); err != nil {
+		return RatMatrix{}, err
+	}
+	if r*c != len(e) {
+		return RatMatrix{}, fmt.Errorf("rows (%d) x columns (%d) is different from the number of matrix elements (%d)", r, c, len(e))
+	}
+
+	m := StartZerosRatMatrix(r, c)
+	for k, s := range e {
+		if _, ok := m.elems[k].SetString(s); !ok {
+			return RatMatrix{}, fmt.Errorf("element [%d][%d] %q is not a rational number", k/c, k%c, s)
+		}
+	}
+
+	return m, nil
+}
+
+// StartZerosRatMatrix starts a rational matrix with zero elements
+// with r rows and c columns. r and c must not be negative, otherwise
+// it panics.
+func StartZerosRatMatrix(r, c int) RatMatrix {
+	if err := checkDims(r, c); err != nil {
+		panic(err.Error())
+	}
+	m := RatMatrix{
+		elems: make([]*big.Rat, r*c),
+		rows:  r,
+		cols:  c,
+	}
+	for k := range m.elems {
+		m.elems[k] = new(big.Rat)
+	}
+
+	return m
+}
+
+// ToRatMatrix returns the rational matrix with the exact values of
+// the elements of the matrix m. An error is generated if m has an
+// infinite or NaN element.
+func ToRatMatrix(m Matrix) (RatMatrix, error) {
+	result := StartZerosRatMatrix(m.rows, m.cols)
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.row(r) {
+			if result.elems[r*m.cols+c].SetFloat64(v) == nil {
+				return RatMatrix{}, fmt.Errorf("element [%d][%d] %v is not a finite number", r, c, v)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Float64 returns the Matrix with the elements of the current
+// matrix, m, rounded to the nearest float64.
+func (m RatMatrix) Float64() Matrix {
+	result := newMatrix(m.rows, m.cols)
+	for k, v := range m.elems {
+		result.elems[k], _ = v.Float64()
+	}
+
+	return result
+}
+
+// Dims returns the number of rows and columns of the current
+// matrix, m.
+func (m RatMatrix) Dims() (int, int) {
+	return m.rows, m.cols
+}
+
+// at returns the element [r][c] of m, without bounds checking.
+func (m RatMatrix) at(r, c int) *big.Rat {
+	return m.elems[r*m.cols+c]
+}
+
+// GetElement returns a copy of the matrix element of the row r and
+// column c. An error is generated if the requested element exceeds
+// the matrix size.
+func (m RatMatrix) GetElement(r, c int) (*big.Rat, error) {
+	if r >= 0 && r < m.rows && c >= 0 && c < m.cols {
+		return new(big.Rat).Set(m.at(r, c)), nil
+	}
+	return nil, fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+}
+
+// SetElement changes the matrix element of row r and column c to a
+// copy of v. An error is generated if the requested element exceeds
+// the matrix size.
+func (m *RatMatrix) SetElement(r, c int, v *big.Rat) error {
+	if r >= 0 && r < m.rows && c >= 0 && c < m.cols {
+		m.at(r, c).Set(v)
+		return nil
+	}
+	return fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+}
+
+// Copy returns a copy of the current matrix, m.
+func (m RatMatrix) Copy() RatMatrix {
+	result := StartZerosRatMatrix(m.rows, m.cols)
+	for k, v := range m.elems {
+		result.elems[k].Set(v)
+	}
+
+	return result
+}
+
+// IsEqual compares the current matrix, m, with the matrix other
+// and returns true if they are equal.
+func (m RatMatrix) IsEqual(other RatMatrix) bool {
+	if m.cols != other.cols || m.rows != other.rows {
+		return false
+	}
+
+	for k, v := range m.elems {
+		if v.Cmp(other.elems[k]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Add returns the addition of the current matrix, m, by the
+// other matrix. An error is generated if the arrays have
+// different sizes.
+func (m RatMatrix) Add(other RatMatrix) (RatMatrix, error) {
+	if m.rows != other.rows || m.cols != other.cols {
+		return RatMatrix{}, fmt.Errorf("matrix %dx%d plus %dx%d: %w", m.rows, m.cols, other.rows, other.cols, ErrDimension)
+	}
+
+	result := StartZerosRatMatrix(m.rows, m.cols)
+	for k, v := range result.elems {
+		v.Add(m.elems[k], other.elems[k])
+	}
+
+	return result, nil
+}
+
+// Sub returns the subtraction of the current matrix, m, by the
+// other matrix. An error is generated if the arrays have different
+// sizes.
+func (m RatMatrix) Sub(other RatMatrix) (RatMatrix, error) {
+	if m.rows != other.rows || m.cols != other.cols {
+		return RatMatrix{}, fmt.Errorf("matrix %dx%d minus %dx%d: %w", m.rows, m.cols, other.rows, other.cols, ErrDimension)
+	}
+
+	result := StartZerosRatMatrix(m.rows, m.cols)
+	for k, v := range result.elems {
+		v.Sub(m.elems[k], other.elems[k])
+	}
+
+	return result, nil
+}
+
+// ScalarProduct returns the product of the current matrix, m, by
+// the rational constant s.
+func (m RatMatrix) ScalarProduct(s *big.Rat) RatMatrix {
+	result := StartZerosRatMatrix(m.rows, m.cols)
+	for k, v := range result.elems {
+		v.Mul(m.elems[k], s)
+	}
+
+	return result
+}
+
+// Product returns the product between the current matrix, m, and
+// the matrix other. An error is generated if the number of columns
+// of m is different from the number of rows of other.
+func (m RatMatrix) Product(other RatMatrix) (RatMatrix, error) {
+	if m.cols != other.rows {
+		return RatMatrix{}, fmt.Errorf("matrix %dx%d times %dx%d: %w", m.rows, m.cols, other.rows, other.cols, ErrDimension)
+	}
+
+	result := StartZerosRatMatrix(m.rows, other.cols)
+	t := new(big.Rat)
+	for r := 0; r < m.rows; r++ {
+		for k := 0; k < m.cols; k++ {
+			a := m.at(r, k)
+			if a.Sign() == 0 {
+				continue
+			}
+			for c := 0; c < other.cols; c++ {
+				dst := result.at(r, c)
+				dst.Add(dst, t.Mul(a, other.at(k, c)))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Transpose returns the transpose of the current matrix, m.
+func (m RatMatrix) Transpose() RatMatrix {
+	result := StartZerosRatMatrix(m.cols, m.rows)
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			result.at(c, r).Set(m.at(r, c))
+		}
+	}
+
+	return result
+}
+
+// Det returns the exact determinant of a square rational matrix,
+// computed by Gaussian elimination. A NotSquareError is returned if
+// the matrix is not square.
+func (m RatMatrix) Det() (*big.Rat, error) {
+	if m.rows != m.cols {
+		return nil, NotSquareError{m.rows, m.cols}
+	}
+
+	a := m.Copy()
+	det := big.NewRat(1, 1)
+	f, t := new(big.Rat), new(big.Rat)
+	for k := 0; k < a.rows; k++ {
+		p := k
+		for p < a.rows && a.at(p, k).Sign() == 0 {
+			p++
+		}
+		if p == a.rows {
+			return new(big.Rat), nil
+		}
+		if p != k {
+			a.swapRows(p, k)
+			det.Neg(det)
+		}
+		det.Mul(det, a.at(k, k))
+		for i := k + 1; i < a.rows; i++ {
+			if a.at(i, k).Sign() == 0 {
+				continue
+			}
+			f.Quo(a.at(i, k), a.at(k, k))
+			for c := k; c < a.cols; c++ {
+				a.at(i, c).Sub(a.at(i, c), t.Mul(f, a.at(k, c)))
+			}
+		}
+	}
+
+	return det, nil
+}
+
+// Inverse returns the exact inverse of the current matrix, m, by
+// reducing [m | I] to [I | m⁻¹]. A NotSquareError is returned if m
+// is not square and ErrSingular if m is singular.
+func (m RatMatrix) Inverse() (RatMatrix, error) {
+	if m.rows != m.cols {
+		return RatMatrix{}, NotSquareError{m.rows, m.cols}
+	}
+
+	n := m.rows
+	if n == 0 {
+		return StartZerosRatMatrix(0, 0), nil
+	}
+
+	aug := StartZerosRatMatrix(n, 2*n)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			aug.at(r, c).Set(m.at(r, c))
+		}
+		aug.at(r, n+r).SetInt64(1)
+	}
+
+	red, pivots := aug.RREF()
+	if len(pivots) < n || pivots[n-1] != n-1 {
+		return RatMatrix{}, ErrSingular
+	}
+
+	result := StartZerosRatMatrix(n, n)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			result.at(r, c).Set(red.at(r, n+c))
+		}
+	}
+
+	return result, nil
+}
+
+// RREF returns the reduced row echelon form of the current matrix,
+// m, and its pivot columns, computed by Gauss-Jordan elimination
+// with exact arithmetic. The rank of m is the number of pivots.
+func (m RatMatrix) RREF() (RatMatrix, []int) {
+	a := m.Copy()
+	pivots := []int{}
+	f, t := new(big.Rat), new(big.Rat)
+	r := 0
+	for c := 0; c < a.cols && r < a.rows; c++ {
+		p := r
+		for p < a.rows && a.at(p, c).Sign() == 0 {
+			p++
+		}
+		if p == a.rows {
+			continue
+		}
+		a.swapRows(p, r)
+
+		f.Inv(a.at(r, c))
+		for j := c; j < a.cols; j++ {
+			a.at(r, j).Mul(a.at(r, j), f)
+		}
+		for i := 0; i < a.rows; i++ {
+			if i == r || a.at(i, c).Sign() == 0 {
+				continue
+			}
+			f.Set(a.at(i, c))
+			for j := c; j < a.cols; j++ {
+				a.at(i, j).Sub(a.at(i, j), t.Mul(f, a.at(r, j)))
+			}
+		}
+		pivots = append(pivots, c)
+		r++
+	}
+
+	return a, pivots
+}
+
+// swapRows swaps the rows i and j of m.
+func (m RatMatrix) swapRows(i, j int) {
+	if i == j {
+		return
+	}
+	ri := m.elems[i*m.cols : (i+1)*m.cols]
+	rj := m.elems[j*m.cols : (j+1)*m.cols]
+	for c := range ri {
+		ri[c], rj[c] = rj[c], ri[c]
+	}
+}
+
+// String creates formatted output for the array, with the elements
+// as signed fractions such as +1/2 and -2/3, in the same layout of
+// Matrix.String, and makes the RatMatrix part of the types that
+// satisfy the fmt.Stringer interface.
+func (m RatMatrix) String() string {
+	if len(m.elems) > 0 {
+		str := "["
+		for r := 0; r < m.rows; r++ {
+			for c := 0; c < m.cols; c++ {
+				v := m.at(r, c)
+				sign := ""
+				if v.Sign() >= 0 {
+					sign = "+"
+				}
+				str += fmt.Sprintf("%3s ", sign+v.RatString())
+			}
+			str = str[:len(str)-1] + "]\n["
+		}
+		str = str[:len(str)-1]
+
+		return str
+	}
+	return "[]\n"
+}
@@ -0,0 +1,114 @@
+package cmath
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestRatMatrixArithmetic(t *testing.T) {
+	a, err := StartRatMatrix(2, 2, "1/2", "1/3", "0.25", "-1")
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	b, _ := StartRatMatrix(2, 2, "1/2", "2/3", "3/4", "1")
+
+	sum, _ := a.Add(b)
+	ans, _ := StartRatMatrix(2, 2, "1", "1", "1", "0")
+	if !sum.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, sum)
+	}
+
+	diff, _ := a.Sub(b)
+	ans, _ = StartRatMatrix(2, 2, "0", "-1/3", "-1/2", "-2")
+	if !diff.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, diff)
+	}
+
+	prod, _ := a.Product(b)
+	ans, _ = StartRatMatrix(2, 2, "1/2", "2/3", "-5/8", "-5/6")
+	if !prod.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, prod)
+	}
+
+	c, _ := StartRatMatrix(1, 2, "1", "2")
+	if _, err := a.Add(c); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+	if _, err := StartRatMatrix(1, 1, "one"); err == nil {
+		t.Error("incorrect result: expected error, invalid element.")
+	}
+
+	m, _ := StartMatrix(1, 2, 0.5, -3)
+	r, _ := ToRatMatrix(m)
+	ans, _ = StartRatMatrix(1, 2, "1/2", "-3")
+	if !r.IsEqual(ans) || !r.Float64().IsEqual(m) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, r)
+	}
+}
+
+func TestRatMatrixDetInverse(t *testing.T) {
+	a, _ := StartRatMatrix(3, 3, "2", "1", "1", "1", "3", "2", "1", "0", "0")
+	det, _ := a.Det()
+	if det.Cmp(big.NewRat(-1, 1)) != 0 {
+		t.Errorf("incorrect result: expected -1, got %v.", det.RatString())
+	}
+
+	h, _ := StartRatMatrix(2, 2, "1", "1/2", "1/2", "1/3")
+	inv, err := h.Inverse()
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	ans, _ := StartRatMatrix(2, 2, "4", "-6", "-6", "12")
+	if !inv.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, inv)
+	}
+
+	b, _ := StartRatMatrix(2, 2, "3", "1", "1", "1")
+	inv, _ = b.Inverse()
+	want := "[+1/2 -1/2]\n[-1/2 +3/2]\n"
+	if inv.String() != want {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", want, inv)
+	}
+	// Same layout of Matrix.String.
+	m, _ := StartMatrix(2, 2, 0.5, -0.5, -0.5, 1.5)
+	if want := "[+0.50 -0.50]\n[-0.50 +1.50]\n"; m.String() != want {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", want, m)
+	}
+
+	e := StartZerosRatMatrix(0, 0)
+	if inv, err := e.Inverse(); err != nil || inv.rows != 0 || inv.cols != 0 {
+		t.Errorf("incorrect result: expected an empty 0x0 matrix, got %dx%d, %v.", inv.rows, inv.cols, err)
+	}
+
+	s, _ := StartRatMatrix(2, 2, "1", "2", "2", "4")
+	if _, err := s.Inverse(); !errors.Is(err, ErrSingular) {
+		t.Errorf("incorrect result: expected ErrSingular, got %v.", err)
+	}
+	if det, _ := s.Det(); det.Sign() != 0 {
+		t.Errorf("incorrect result: expected 0, got %v.", det.RatString())
+	}
+}
+
+func TestRatMatrixRREF(t *testing.T) {
+	a, _ := StartRatMatrix(3, 4,
+		"1", "2", "-1", "-4",
+		"2", "3", "-1", "-11",
+		"-2", "0", "-3", "22")
+	red, pivots := a.RREF()
+	ans, _ := StartRatMatrix(3, 4,
+		"1", "0", "0", "-8",
+		"0", "1", "0", "1",
+		"0", "0", "1", "-2")
+	if !red.IsEqual(ans) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, red)
+	}
+	if len(pivots) != 3 || pivots[2] != 2 {
+		t.Errorf("incorrect result: expected pivots [0 1 2], got %v.", pivots)
+	}
+
+	b, _ := StartRatMatrix(2, 3, "1", "2", "3", "2", "4", "6")
+	if _, pivots := b.RREF(); len(pivots) != 1 {
+		t.Errorf("incorrect result: expected rank 1, got %d.", len(pivots))
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// RowOpKind is the kind of an elementary row operation.
+type RowOpKind int
+
+const (
+	// RowSwap swaps the rows Target and Source.
+	RowSwap RowOpKind = iota
+	// RowScale multiplies the row Target by Factor.
+	RowScale
+	// RowAdd adds Factor times the row Source to the row Target.
+	RowAdd
+)
+
+// RowOp is an elementary row operation. Target and Source are row
+// indexes starting at zero, although String numbers the rows from
+// one, as they are written by hand.
+type RowOp struct {
+	Kind   RowOpKind
+	Target int
+	Source int
+	Factor float64
+}
+
+// String returns the operation in the usual notation, such as
+// "R1 ↔ R2", "R1 ← 1/2·R1" written as "R1 ← 0.5·R1", or
+// "R2 ← R2 − 3·R1".
+func (op RowOp) String() string {
+	switch op.Kind {
+	case RowSwap:
+		return fmt.Sprintf("R%d ↔ R%d", op.Target+1, op.Source+1)
+	case RowScale:
+		return fmt.Sprintf("R%d ← %g·R%d", op.Target+1, op.Factor, op.Target+1)
+	case RowAdd:
+		sign := "+"
+		if op.Factor < 0 {
+			sign = "−"
+		}
+		return fmt.Sprintf("R%d ← R%d %s %g·R%d", op.Target+1, op.Target+1, sign, math.Abs(op.Factor), op.Source+1)
+	}
+
+	return fmt.Sprintf("unknown row operation %d", op.Kind)
+}
+
+// apply executes the operation on the matrix m.
+func (op RowOp) apply(m Matrix) {
+	t := m.row(op.Target)
+	switch op.Kind {
+	case RowSwap:
+		s := m.row(op.Source)
+		for c := range t {
+			t[c], s[c] = s[c], t[c]
+		}
+	case RowScale:
+		for c := range t {
+			t[c] *= op.Factor
+		}
+	case RowAdd:
+		s := m.row(op.Source)
+		for c := range t {
+			t[c] += op.Factor * s[c]
+		}
+	}
+}
+
+/*
+RREF holds the reduced row echelon form of a matrix and the
+elementary row operations, in order, that produced it from the
+original matrix, up to the rounding corrections described in
+Matrix.RREF. Pivots has the pivot column of each nonzero row
+of the form, so the rank is its length.
+*/
+type RREF struct {
+	Matrix Matrix
+	Pivots []int
+	Steps  []RowOp
+}
+
+// Rank returns the rank of the reduced matrix, the number of its
+// pivots.
+func (f RREF) Rank() int {
+	return len(f.Pivots)
+}
+
+// String returns the numbered list of row operations, one per line,
+// followed by the reduced matrix.
+func (f RREF) String() string {
+	var sb strings.Builder
+	for k, op := range f.Steps {
+		fmt.Fprintf(&sb, "%d. %v\n", k+1, op)
+	}
+	sb.WriteString(f.Matrix.String())
+
+	return sb.String()
+}
+
+/*
+RREF returns the reduced row echelon form of the current matrix, m,
+by Gauss-Jordan elimination, with the trace of every row operation
+applied. For each column, the row with the largest element is
+chosen as pivot, swapped into place and scaled to one, and the
+column is eliminated from all the other rows.
+
+Elements smaller than n*max|m|*epsilon, with n the larger dimension
+of m, are rounding residues and are treated as zeros, so that the
+rank is not inflated by them.
+
+The trace is exact only up to that tolerance: the pivots are stored
+as exactly one, the eliminated elements and the residues as exactly
+zero, and these corrections are not row operations, so they are not
+in Steps. Replaying Steps on m reproduces Matrix within rounding
+errors of the order of the tolerance, not bit for bit.
+*/
+func (m Matrix) RREF() RREF {
+	a := newMatrix(m.rows, m.cols)
+	amax := 0.
+	for r := 0; r < m.rows; r++ {
+		copy(a.row(r), m.row(r))
+		for _, v := range m.row(r) {
+			amax = math.Max(amax, math.Abs(v))
+		}
+	}
+	tol := float64(max(m.rows, m.cols)) * amax * epsilon
+
+	f := RREF{Matrix: a, Pivots: []int{}}
+	step := func(op RowOp) {
+		op.apply(a)
+		f.Steps = append(f.Steps, op)
+	}
+
+	r := 0
+	for c := 0; c < a.cols && r < a.rows; c++ {
+		p := r
+		for i := r + 1; i < a.rows; i++ {
+			if math.Abs(a.at(i, c)) > math.Abs(a.at(p, c)) {
+				p = i
+			}
+		}
+		if math.Abs(a.at(p, c)) <= tol {
+			for i := r; i < a.rows; i++ {
+				a.elems[i*a.stride+c] = 0.
+			}
+			continue
+		}
+
+		if p != r {
+			step(RowOp{Kind: RowSwap, Target: r, Source: p})
+		}
+		if v := a.at(r, c); v != 1. {
+			step(RowOp{Kind: RowScale, Target: r, Factor: 1. / v})
+		}
+		a.elems[r*a.stride+c] = 1.
+		for i := 0; i < a.rows; i++ {
+			if i == r || a.at(i, c) == 0. {
+				continue
+			}
+			step(RowOp{Kind: RowAdd, Target: i, Source: r, Factor: -a.at(i, c)})
+			a.elems[i*a.stride+c] = 0.
+		}
+
+		f.Pivots = append(f.Pivots, c)
+		r++
+	}
+
+	return f
+}
@@ -0,0 +1,101 @@
+package cmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRREF(t *testing.T) {
+	a, _ := StartMatrix(3, 4,
+		1, 2, -1, -4,
+		2, 3, -1, -11,
+		-2, 0, -3, 22)
+	f := a.RREF()
+
+	ans, _ := StartMatrix(3, 4,
+		1, 0, 0, -8,
+		0, 1, 0, 1,
+		0, 0, 1, -2)
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 4; c++ {
+			if math.Abs(f.Matrix.at(r, c)-ans.at(r, c)) > 1e-12 {
+				t.Fatalf("incorrect result: expected \n%v, got\n%v.", ans, f.Matrix)
+			}
+		}
+	}
+	if f.Rank() != 3 {
+		t.Errorf("incorrect result: expected rank 3, got %d.", f.Rank())
+	}
+
+	// Replaying the trace on the original matrix gives the form.
+	replay := newMatrix(3, 4)
+	copy(replay.elems, a.elems)
+	for _, op := range f.Steps {
+		op.apply(replay)
+	}
+	for k := range replay.elems {
+		if math.Abs(replay.elems[k]-f.Matrix.elems[k]) > 1e-12 {
+			t.Fatalf("incorrect result: expected \n%v, got\n%v.", f.Matrix, replay)
+		}
+	}
+}
+
+func TestRREFRankDeficient(t *testing.T) {
+	a, _ := StartMatrix(3, 3, 1, 2, 3, 2, 4, 6, 1, 1, 1)
+	f := a.RREF()
+	if f.Rank() != 2 || f.Pivots[0] != 0 || f.Pivots[1] != 1 {
+		t.Errorf("incorrect result: expected pivots [0 1], got %v.", f.Pivots)
+	}
+	for c := 0; c < 3; c++ {
+		if f.Matrix.at(2, c) != 0. {
+			t.Fatalf("incorrect result: expected zero last row, got\n%v.", f.Matrix)
+		}
+	}
+}
+
+func TestRowOpString(t *testing.T) {
+	tests := []struct {
+		op  RowOp
+		ans string
+	}{
+		{RowOp{Kind: RowSwap, Target: 0, Source: 1}, "R1 ↔ R2"},
+		{RowOp{Kind: RowScale, Target: 1, Factor: 0.5}, "R2 ← 0.5·R2"},
+		{RowOp{Kind: RowAdd, Target: 1, Source: 0, Factor: -3}, "R2 ← R2 − 3·R1"},
+		{RowOp{Kind: RowAdd, Target: 2, Source: 1, Factor: 2}, "R3 ← R3 + 2·R2"},
+	}
+	for _, test := range tests {
+		if s := test.op.String(); s != test.ans {
+			t.Errorf("incorrect result: expected %q, got %q.", test.ans, s)
+		}
+	}
+
+	a, _ := StartMatrix(2, 2, 1, 2, 3, 4)
+	f := a.RREF()
+	ans := "1. R1 ↔ R2\n2. R1 ← 0.3333333333333333·R1\n"
+	if s := f.String(); len(s) < len(ans) || s[:len(ans)] != ans {
+		t.Errorf("incorrect result: expected prefix %q, got %q.", ans, s)
+	}
+}
+
+func TestRREFReplayTolerance(t *testing.T) {
+	// Row 2 is 3*row 1 in exact arithmetic, but not in float64, so
+	// the elimination leaves residues that are set to zero.
+	a, _ := StartMatrix(3, 3,
+		0.1, 0.7, 0.3,
+		0.3, 2.1, 0.9,
+		0.2, 0.5, 1.3)
+	f := a.RREF()
+	if f.Rank() != 2 {
+		t.Fatalf("incorrect result: expected rank 2, got %d.", f.Rank())
+	}
+
+	replay := newMatrix(3, 3)
+	copy(replay.elems, a.elems)
+	for _, op := range f.Steps {
+		op.apply(replay)
+	}
+	tol := 3 * 2.1 * epsilon
+	if !replay.ApproxEqual(f.Matrix, 10*tol, 0) {
+		t.Errorf("incorrect result: expected replay within %v of\n%v, got\n%v.", 10*tol, f.Matrix, replay)
+	}
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+COO is a sparse matrix in coordinate format, a list of (row,
+column, value) triplets. It is the convenient format to assemble a
+sparse matrix element by element, as in circuit or finite element
+problems, and must be converted to CSR to operate.
+*/
+type COO struct {
+	rows int
+	cols int
+	ri   []int
+	ci   []int
+	v    []float64
+}
+
+// StartCOO starts an empty r x c sparse matrix in coordinate format.
+// It panics if r or c is negative, as StartZerosMatrix.
+func StartCOO(r, c int) *COO {
+	if err := checkDims(r, c); err != nil {
+		panic(err.Error())
+	}
+
+	return &COO{rows: r, cols: c}
+}
+
+// Append adds the value v to the element of row r and column c.
+// Repeated elements are summed when the matrix is converted to
+// CSR. An error is generated if the element exceeds the matrix
+// size.
+func (s *COO) Append(r, c int, v float64) error {
+	if r < 0 || r >= s.rows || c < 0 || c >= s.cols {
+		return fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+	}
+
+	s.ri = append(s.ri, r)
+	s.ci = append(s.ci, c)
+	s.v = append(s.v, v)
+
+	return nil
+}
+
+// ToCSR converts the matrix to the compressed sparse row format,
+// summing the repeated elements and discarding the zeros.
+func (s *COO) ToCSR() CSR {
+	idx := make([]int, len(s.v))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		a, b := idx[i], idx[j]
+		if s.ri[a] != s.ri[b] {
+			return s.ri[a] < s.ri[b]
+		}
+		return s.ci[a] < s.ci[b]
+	})
+
+	csr := CSR{
+		rows:   s.rows,
+		cols:   s.cols,
+		rowPtr: make([]int, s.rows+1),
+	}
+	for k := 0; k < len(idx); {
+		r, c := s.ri[idx[k]], s.ci[idx[k]]
+		v := 0.
+		for ; k < len(idx) && s.ri[idx[k]] == r && s.ci[idx[k]] == c; k++ {
+			v += s.v[idx[k]]
+		}
+		if v == 0. {
+			continue
+		}
+		csr.colIdx = append(csr.colIdx, c)
+		csr.vals = append(csr.vals, v)
+		csr.rowPtr[r+1]++
+	}
+	for r := 0; r < s.rows; r++ {
+		csr.rowPtr[r+1] += csr.rowPtr[r]
+	}
+
+	return csr
+}
+
+/*
+CSR is a sparse matrix in compressed sparse row format. Only the
+nonzero elements are stored: the values and column indexes of row
+r are in vals[rowPtr[r]:rowPtr[r+1]] and colIdx[rowPtr[r]:rowPtr[r+1]].
+
+The memory used is proportional to the number of nonzero elements,
+which makes it possible to hold matrices that are too large for
+the dense Matrix.
+*/
+type CSR struct {
+	rows   int
+	cols   int
+	rowPtr []int
+	colIdx []int
+	vals   []float64
+}
+
+// ToCSR converts the current matrix, m, to the compressed sparse
+// row format.
+func (m Matrix) ToCSR() CSR {
+	csr := CSR{
+		rows:   m.rows,
+		cols:   m.cols,
+		rowPtr: make([]int, m.rows+1),
+	}
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.row(r) {
+			if v != 0. {
+				csr.colIdx = append(csr.colIdx, c)
+				csr.vals = append(csr.vals, v)
+			}
+		}
+		csr.rowPtr[r+1] = len(csr.vals)
+	}
+
+	return csr
+}
+
+// Dims returns the number of rows and columns of the matrix.
+func (s CSR) Dims() (int, int) {
+	return s.rows, s.cols
+}
+
+// NNZ returns the number of nonzero elements stored.
+func (s CSR) NNZ() int {
+	return len(s.vals)
+}
+
+// GetElement returns the element of row r and column c. An error
+// is generated if the requested element exceeds the matrix size.
+func (s CSR) GetElement(r, c int) (float64, error) {
+	if r < 0 || r >= s.rows || c < 0 || c >= s.cols {
+		return 0., fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+	}
+
+	cols := s.colIdx[s.rowPtr[r]:s.rowPtr[r+1]]
+	k := sort.SearchInts(cols, c)
+	if k < len(cols) && cols[k] == c {
+		return s.vals[s.rowPtr[r]+k], nil
+	}
+
+	return 0., nil
+}
+
+// ToMatrix converts the sparse matrix to a dense Matrix.
+func (s CSR) ToMatrix() Matrix {
+	m := StartZerosMatrix(s.rows, s.cols)
+	for r := 0; r < s.rows; r++ {
+		row := m.row(r)
+		for k := s.rowPtr[r]; k < s.rowPtr[r+1]; k++ {
+			row[s.colIdx[k]] = s.vals[k]
+		}
+	}
+
+	return m
+}
+
+// Transpose returns the transpose of the sparse matrix.
+func (s CSR) Transpose() CSR {
+	t := CSR{
+		rows:   s.cols,
+		cols:   s.rows,
+		rowPtr: make([]int, s.cols+1),
+		colIdx: make([]int, len(s.vals)),
+		vals:   make([]float64, len(s.vals)),
+	}
+
+	for _, c := range s.colIdx {
+		t.rowPtr[c+1]++
+	}
+	for c := 0; c < s.cols; c++ {
+		t.rowPtr[c+1] += t.rowPtr[c]
+	}
+	next := make([]int, s.cols)
+	copy(next, t.rowPtr[:s.cols])
+	for r := 0; r < s.rows; r++ {
+		for k := s.rowPtr[r]; k < s.rowPtr[r+1]; k++ {
+			c := s.colIdx[k]
+			t.colIdx[next[c]] = r
+			t.vals[next[c]] = s.vals[k]
+			next[c]++
+		}
+	}
+
+	return t
+}
+
+// MulVec returns the product of the sparse matrix by the vector x.
+// An error is generated if the length of x is different from the
+// number of columns.
+func (s CSR) MulVec(x []float64) ([]float64, error) {
+	y := make([]float64, s.rows)
+	if err := s.MulVecTo(y, x); err != nil {
+		return nil, err
+	}
+
+	return y, nil
+}
+
+// MulVecTo stores the product of the sparse matrix by the vector x
+// in dst, without allocations. An error is generated if the
+// lengths of x or dst do not match the matrix size.
+func (s CSR) MulVecTo(dst, x []float64) error {
+	if len(x) != s.cols || len(dst) != s.rows {
+		return fmt.Errorf("A*x with A %dx%d needs x with %d and dst with %d elements: %w", s.rows, s.cols, s.cols, s.rows, ErrDimension)
+	}
+
+	for r := 0; r < s.rows; r++ {
+		sum := 0.
+		for k := s.rowPtr[r]; k < s.rowPtr[r+1]; k++ {
+			sum += s.vals[k] * x[s.colIdx[k]]
+		}
+		dst[r] = sum
+	}
+
+	return nil
+}
+
+// Solve returns the solution x of the sparse system A*x = b by
+// the stabilized biconjugate gradient method (BiCGSTAB) with Jacobi
+// preconditioning, which accepts nonsymmetric matrices. The
+// iterations stop when the norm of the residual b - A*x is below
+// tol times the norm of b. A NotSquareError is returned if A is not
+// square and a ConvergenceError if the precision is not reached in
+// maxIter iterations.
+func (s CSR) Solve(b []float64, tol float64, maxIter int) ([]float64, error) {
+	if s.rows != s.cols {
+		return nil, NotSquareError{s.rows, s.cols}
+	}
+	if len(b) != s.rows {
+		return nil, fmt.Errorf("b has %d elements, expected %d: %w", len(b), s.rows, ErrDimension)
+	}
+
+	n := s.rows
+	x := make([]float64, n)
+	bnorm := norm2(b)
+	if bnorm == 0. {
+		return x, nil
+	}
+
+	// Inverse of the diagonal, the Jacobi preconditioner.
+	dinv := make([]float64, n)
+	for r := 0; r < n; r++ {
+		dinv[r] = 1.
+		if d, _ := s.GetElement(r, r); d != 0. {
+			dinv[r] = 1. / d
+		}
+	}
+
+	r := make([]float64, n)
+	copy(r, b)
+	rhat := make([]float64, n)
+	copy(rhat, b)
+	p := make([]float64, n)
+	v := make([]float64, n)
+	ph := make([]float64, n)
+	sh := make([]float64, n)
+	t := make([]float64, n)
+	rho, alpha, omega := 1., 1., 1.
+
+	for iter := 1; iter <= maxIter; iter++ {
+		rhoNew := dot(rhat, r)
+		if rhoNew == 0. {
+			return nil, fmt.Errorf("BiCGSTAB breakdown at iteration %d", iter)
+		}
+		beta := (rhoNew / rho) * (alpha / omega)
+		for i := range p {
+			p[i] = r[i] + beta*(p[i]-omega*v[i])
+			ph[i] = dinv[i] * p[i]
+		}
+		s.MulVecTo(v, ph)
+		rv := dot(rhat, v)
+		if rv == 0. {
+			return nil, fmt.Errorf("BiCGSTAB breakdown at iteration %d", iter)
+		}
+		alpha = rhoNew / rv
+
+		// r now holds s = r - alpha*v.
+		for i := range r {
+			r[i] -= alpha * v[i]
+		}
+		if norm2(r) <= tol*bnorm {
+			for i := range x {
+				x[i] += alpha * ph[i]
+			}
+			return x, nil
+		}
+
+		for i := range sh {
+			sh[i] = dinv[i] * r[i]
+		}
+		s.MulVecTo(t, sh)
+		tt := dot(t, t)
+		if tt == 0. {
+			return nil, fmt.Errorf("BiCGSTAB breakdown at iteration %d", iter)
+		}
+		omega = dot(t, r) / tt
+		for i := range x {
+			x[i] += alpha*ph[i] + omega*sh[i]
+			r[i] -= omega * t[i]
+		}
+		if norm2(r) <= tol*bnorm {
+			return x, nil
+		}
+		if omega == 0. {
+			return nil, fmt.Errorf("BiCGSTAB breakdown at iteration %d", iter)
+		}
+		rho = rhoNew
+	}
+
+	return nil, ConvergenceError{"BiCGSTAB", maxIter}
+}
+
+// dot returns the dot product of the slices x and y.
+func dot(x, y []float64) float64 {
+	s := 0.
+	for i := range x {
+		s += x[i] * y[i]
+	}
+
+	return s
+}
+
+// norm2 returns the Euclidean norm of the slice x.
+func norm2(x []float64) float64 {
+	return math.Sqrt(dot(x, x))
+}
@@ -0,0 +1,116 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCOO(t *testing.T) {
+	coo := StartCOO(3, 3)
+	if err := coo.Append(3, 0, 1); err == nil {
+		t.Error("incorrect result: expected error.")
+	}
+	coo.Append(2, 1, 4)
+	coo.Append(0, 0, 1)
+	coo.Append(1, 2, 3)
+	coo.Append(0, 0, 1)
+	coo.Append(1, 1, 5)
+	coo.Append(1, 1, -5)
+
+	csr := coo.ToCSR()
+	if csr.NNZ() != 3 {
+		t.Errorf("incorrect result: expected 3 nonzeros, got %d.", csr.NNZ())
+	}
+	ans, _ := StartMatrix(3, 3, 2, 0, 0, 0, 0, 3, 0, 4, 0)
+	if m := csr.ToMatrix(); !ans.IsEqual(m) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, m)
+	}
+	if v, _ := csr.GetElement(1, 2); v != 3. {
+		t.Errorf("incorrect result: expected 3, got %v.", v)
+	}
+	if v, _ := csr.GetElement(1, 0); v != 0. {
+		t.Errorf("incorrect result: expected 0, got %v.", v)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("incorrect result: expected panic, negative dimensions.")
+		}
+	}()
+	StartCOO(-1, 2)
+}
+
+func TestCSR(t *testing.T) {
+	m, _ := StartMatrix(2, 3, 1, 0, 2, 0, 3, 0)
+	csr := m.ToCSR()
+	if r, c := csr.Dims(); r != 2 || c != 3 || csr.NNZ() != 3 {
+		t.Errorf("incorrect result: expected 2x3 with 3 nonzeros, got %dx%d with %d.", r, c, csr.NNZ())
+	}
+	if back := csr.ToMatrix(); !m.IsEqual(back) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", m, back)
+	}
+	if tr := csr.Transpose().ToMatrix(); !m.Transpose().IsEqual(tr) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", m.Transpose(), tr)
+	}
+
+	y, err := csr.MulVec([]float64{1, 2, 3})
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	if y[0] != 7. || y[1] != 6. {
+		t.Errorf("incorrect result: expected [7 6], got %v.", y)
+	}
+	if _, err := csr.MulVec([]float64{1, 2}); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+}
+
+func TestCSRSolve(t *testing.T) {
+	// Convection-diffusion operator, nonsymmetric and tridiagonal.
+	const n = 2000
+	coo := StartCOO(n, n)
+	for i := 0; i < n; i++ {
+		coo.Append(i, i, 2.5)
+		if i > 0 {
+			coo.Append(i, i-1, -1.2)
+		}
+		if i < n-1 {
+			coo.Append(i, i+1, -0.8)
+		}
+	}
+	a := coo.ToCSR()
+
+	xans := make([]float64, n)
+	for i := range xans {
+		xans[i] = math.Sin(float64(i) / 100.)
+	}
+	b, _ := a.MulVec(xans)
+
+	x, err := a.Solve(b, 1e-12, 1000)
+	if err != nil {
+		t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+	}
+	for i := range x {
+		if math.Abs(x[i]-xans[i]) > 1e-9 {
+			t.Fatalf("incorrect result: x[%d] = %v, expected %v.", i, x[i], xans[i])
+		}
+	}
+
+	var convErr ConvergenceError
+	if _, err := a.Solve(b, 1e-12, 2); !errors.As(err, &convErr) {
+		t.Errorf("incorrect result: expected ConvergenceError, got %v.", err)
+	}
+}
+
+func TestCSRSolveBreakdown(t *testing.T) {
+	// With b = (1, -1) the first direction v = A*b is orthogonal to
+	// b, so alpha would divide by zero.
+	m, _ := StartMatrix(2, 2, 1, 2, 0, 1)
+	a := m.ToCSR()
+	_, err := a.Solve([]float64{1, -1}, 1e-12, 100)
+	if err == nil || !strings.Contains(err.Error(), "breakdown") {
+		t.Errorf("incorrect result: expected breakdown error, got %v.", err)
+	}
+}
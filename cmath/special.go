@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// StartIdentityMatrix starts the identity matrix of order n.
+func StartIdentityMatrix(n int) Matrix {
+	m := StartZerosMatrix(n, n)
+	for k := 0; k < n; k++ {
+		m.elems[k*m.stride+k] = 1.
+	}
+
+	return m
+}
+
+// StartDiagMatrix starts a square matrix with the elements d on
+// the main diagonal and zeros elsewhere. Use StartDiagonal for the
+// compact Diagonal type.
+func StartDiagMatrix(d ...float64) Matrix {
+	m := StartZerosMatrix(len(d), len(d))
+	for k, v := range d {
+		m.elems[k*m.stride+k] = v
+	}
+
+	return m
+}
+
+// StartHilbertMatrix starts the Hilbert matrix of order n, with
+// the elements 1/(r+c+1). It is a classic ill-conditioned test
+// matrix: its condition number grows like e^(3.5n).
+func StartHilbertMatrix(n int) Matrix {
+	m := StartZerosMatrix(n, n)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			m.elems[r*m.stride+c] = 1. / float64(r+c+1)
+		}
+	}
+
+	return m
+}
+
+// StartVandermondeMatrix starts the square Vandermonde matrix of
+// the points x, whose row r has the powers x[r]^0, x[r]^1, ...,
+// x[r]^(n-1). Solving it for the values y gives the coefficients of
+// the polynomial that interpolates the points (x, y).
+func StartVandermondeMatrix(x ...float64) Matrix {
+	n := len(x)
+	m := StartZerosMatrix(n, n)
+	for r, v := range x {
+		p := 1.
+		for c := 0; c < n; c++ {
+			m.elems[r*m.stride+c] = p
+			p *= v
+		}
+	}
+
+	return m
+}
+
+// StartToeplitzMatrix starts the Toeplitz matrix with the first
+// column col and the first row row, constant along each diagonal,
+// so that the element [r][c] is col[r-c] below the diagonal and
+// row[c-r] above it. An error is generated if col[0] and row[0],
+// both the first element, are different.
+func StartToeplitzMatrix(col, row []float64) (Matrix, error) {
+	if len(col) == 0 || len(row) == 0 {
+		return StartZerosMatrix(len(col), len(row)), nil
+	}
+	if col[0] != row[0] {
+		return Matrix{}, fmt.Errorf("the first element of the column (%v) and of the row (%v) must be equal", col[0], row[0])
+	}
+
+	m := StartZerosMatrix(len(col), len(row))
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			if r >= c {
+				m.elems[r*m.stride+c] = col[r-c]
+			} else {
+				m.elems[r*m.stride+c] = row[c-r]
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// StartCirculantMatrix starts the circulant matrix with the first
+// row c, where each row is the previous one rotated one position to
+// the right.
+func StartCirculantMatrix(c ...float64) Matrix {
+	n := len(c)
+	m := StartZerosMatrix(n, n)
+	for r := 0; r < n; r++ {
+		for k, v := range c {
+			m.elems[r*m.stride+(r+k)%n] = v
+		}
+	}
+
+	return m
+}
+
+// StartRandomMatrix starts a r x c matrix with random elements
+// uniformly distributed in [0, 1). The same seed always gives the
+// same matrix.
+func StartRandomMatrix(r, c int, seed int64) Matrix {
+	rnd := rand.New(rand.NewSource(seed))
+	m := StartZerosMatrix(r, c)
+	for k := range m.elems {
+		m.elems[k] = rnd.Float64()
+	}
+
+	return m
+}
+
+// StartNormalMatrix starts a r x c matrix with random elements of
+// the standard normal distribution, with mean 0 and standard
+// deviation 1. The same seed always gives the same matrix.
+func StartNormalMatrix(r, c int, seed int64) Matrix {
+	rnd := rand.New(rand.NewSource(seed))
+	m := StartZerosMatrix(r, c)
+	for k := range m.elems {
+		m.elems[k] = rnd.NormFloat64()
+	}
+
+	return m
+}
+
+// StartOrthogonalMatrix starts a random n x n orthogonal matrix Q,
+// with Qᵀ*Q = I, uniformly distributed over the orthogonal group.
+// It is the Q factor of the QR decomposition of a normal random
+// matrix, with the signs of its columns fixed so that R has a
+// positive diagonal. The same seed always gives the same matrix.
+func StartOrthogonalMatrix(n int, seed int64) Matrix {
+	for {
+		qr, _ := StartNormalMatrix(n, n, seed).QR()
+		if !qr.IsFullRank() {
+			// Practically impossible for a normal random matrix.
+			seed++
+			continue
+		}
+
+		q, r := qr.Q(), qr.R()
+		for c := 0; c < n; c++ {
+			if math.Signbit(r.at(c, c)) {
+				for i := 0; i < n; i++ {
+					q.elems[i*q.stride+c] = -q.elems[i*q.stride+c]
+				}
+			}
+		}
+
+		return q
+	}
+}
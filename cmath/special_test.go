@@ -0,0 +1,75 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestStartSpecialMatrices(t *testing.T) {
+	tests := []struct {
+		m   Matrix
+		ans Matrix
+	}{
+		{StartIdentityMatrix(2), must(StartMatrix(2, 2, 1, 0, 0, 1))},
+		{StartDiagMatrix(2, -3), must(StartMatrix(2, 2, 2, 0, 0, -3))},
+		{StartHilbertMatrix(2), must(StartMatrix(2, 2, 1, 0.5, 0.5, 1./3))},
+		{StartVandermondeMatrix(1, 2, 3), must(StartMatrix(3, 3, 1, 1, 1, 1, 2, 4, 1, 3, 9))},
+		{must(StartToeplitzMatrix([]float64{1, 2, 3}, []float64{1, 4})), must(StartMatrix(3, 2, 1, 4, 2, 1, 3, 2))},
+		{StartCirculantMatrix(1, 2, 3), must(StartMatrix(3, 3, 1, 2, 3, 3, 1, 2, 2, 3, 1))},
+	}
+	for _, test := range tests {
+		if !test.m.IsEqual(test.ans) {
+			t.Errorf("incorrect result: expected \n%v, got\n%v.", test.ans, test.m)
+		}
+	}
+
+	if _, err := StartToeplitzMatrix([]float64{1, 2}, []float64{2, 1}); err == nil {
+		t.Error("incorrect result: expected error, different first elements.")
+	}
+
+	// Det of the Vandermonde matrix is the product of x[j] - x[i].
+	det, _ := StartVandermondeMatrix(1, 2, 4, 7).Det()
+	if ans := 1. * 3 * 6 * 2 * 5 * 3; math.Abs(det-ans) > 1e-9 {
+		t.Errorf("incorrect result: expected det %v, got %v.", ans, det)
+	}
+}
+
+func TestStartRandomMatrices(t *testing.T) {
+	u := StartRandomMatrix(20, 30, 42)
+	if !u.IsEqual(StartRandomMatrix(20, 30, 42)) {
+		t.Error("incorrect result: the same seed must give the same matrix.")
+	}
+	if u.IsEqual(StartRandomMatrix(20, 30, 43)) {
+		t.Error("incorrect result: different seeds must give different matrices.")
+	}
+	for _, v := range u.elems {
+		if v < 0 || v >= 1 {
+			t.Fatalf("incorrect result: expected element in [0, 1), got %v.", v)
+		}
+	}
+
+	n := StartNormalMatrix(100, 100, 1)
+	mean, sq := 0., 0.
+	for _, v := range n.elems {
+		mean += v
+		sq += v * v
+	}
+	mean /= 1e4
+	if math.Abs(mean) > 0.05 || math.Abs(sq/1e4-1) > 0.05 {
+		t.Errorf("incorrect result: expected mean 0 and variance 1, got %v and %v.", mean, sq/1e4)
+	}
+
+	q := StartOrthogonalMatrix(8, 3)
+	qtq, _ := q.Transpose().Product(q)
+	if !qtq.ApproxEqual(StartIdentityMatrix(8), 1e-13, 0) {
+		t.Errorf("incorrect result: expected QᵀQ = I, got\n%v.", qtq)
+	}
+	if det, _ := q.Det(); math.Abs(math.Abs(det)-1) > 1e-12 {
+		t.Errorf("incorrect result: expected |det Q| = 1, got %v.", det)
+	}
+	var nse NotSquareError
+	if _, err := StartRandomMatrix(2, 3, 0).Det(); !errors.As(err, &nse) {
+		t.Errorf("incorrect result: expected NotSquareError, got %v.", err)
+	}
+}
@@ -0,0 +1,366 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"fmt"
+)
+
+// outOfRange returns the error of a nonexistent element [r][c].
+func outOfRange(r, c int) error {
+	return fmt.Errorf("there is not element [%d][%d] in this matrix", r, c)
+}
+
+// Diagonal is a square matrix whose nonzero elements are all on the
+// main diagonal. Only the n diagonal elements are stored.
+type Diagonal struct {
+	d []float64
+}
+
+// StartDiagonal starts a diagonal matrix with the diagonal
+// elements d.
+func StartDiagonal(d ...float64) Diagonal {
+	e := make([]float64, len(d))
+	copy(e, d)
+
+	return Diagonal{e}
+}
+
+// Dims returns the number of rows and columns of the matrix.
+func (m Diagonal) Dims() (int, int) {
+	return len(m.d), len(m.d)
+}
+
+// At returns the element [r][c]. It panics if the element does
+// not exist.
+func (m Diagonal) At(r, c int) float64 {
+	if r < 0 || r >= len(m.d) || c < 0 || c >= len(m.d) {
+		panic(outOfRange(r, c).Error())
+	}
+	if r != c {
+		return 0.
+	}
+
+	return m.d[r]
+}
+
+// Set changes the element [r][c] to v. An error is generated if
+// the element does not exist or if it is out of the diagonal and v
+// is not zero.
+func (m Diagonal) Set(r, c int, v float64) error {
+	if r < 0 || r >= len(m.d) || c < 0 || c >= len(m.d) {
+		return outOfRange(r, c)
+	}
+	if r != c {
+		if v != 0. {
+			return fmt.Errorf("element [%d][%d] of a diagonal matrix must be zero", r, c)
+		}
+		return nil
+	}
+	m.d[r] = v
+
+	return nil
+}
+
+// T returns the transpose, which is the matrix itself.
+func (m Diagonal) T() Mat {
+	return m
+}
+
+// Det returns the determinant, the product of the diagonal.
+func (m Diagonal) Det() float64 {
+	det := 1.
+	for _, v := range m.d {
+		det *= v
+	}
+
+	return det
+}
+
+// triangular holds the elements of a n x n triangular matrix in a
+// dense Matrix, shared by UpperTriangular and LowerTriangular.
+type triangular struct {
+	m Matrix
+}
+
+// Dims returns the number of rows and columns of the matrix.
+func (t triangular) Dims() (int, int) {
+	return t.m.rows, t.m.cols
+}
+
+// At returns the element [r][c]. It panics if the element does
+// not exist.
+func (t triangular) At(r, c int) float64 {
+	return t.m.At(r, c)
+}
+
+// Det returns the determinant, the product of the diagonal.
+func (t triangular) Det() float64 {
+	det := 1.
+	for k := 0; k < t.m.rows; k++ {
+		det *= t.m.at(k, k)
+	}
+
+	return det
+}
+
+// set changes the element [r][c] to v, if it is in the triangle
+// or v is zero.
+func (t triangular) set(r, c int, v float64, inside bool, kind string) error {
+	if r < 0 || r >= t.m.rows || c < 0 || c >= t.m.cols {
+		return outOfRange(r, c)
+	}
+	if !inside {
+		if v != 0. {
+			return fmt.Errorf("element [%d][%d] of a %s triangular matrix must be zero", r, c, kind)
+		}
+		return nil
+	}
+
+	return t.m.SetElement(r, c, v)
+}
+
+// startTriangular returns the n x n triangular storage with the
+// elements e of the upper or lower triangle, row by row.
+func startTriangular(n int, upper bool, e []float64) (triangular, error) {
+	if err := checkDims(n, n); err != nil {
+		return triangular{}, err
+	}
+	if len(e) != n*(n+1)/2 {
+		return triangular{}, fmt.Errorf("a %dx%d triangular matrix has %d elements, got %d", n, n, n*(n+1)/2, len(e))
+	}
+
+	t := triangular{StartZerosMatrix(n, n)}
+	k := 0
+	for r := 0; r < n; r++ {
+		c0, c1 := 0, r+1
+		if upper {
+			c0, c1 = r, n
+		}
+		for c := c0; c < c1; c++ {
+			t.m.elems[r*n+c] = e[k]
+			k++
+		}
+	}
+
+	return t, nil
+}
+
+// UpperTriangular is a square matrix whose elements below the main
+// diagonal are zeros.
+type UpperTriangular struct {
+	triangular
+}
+
+// StartUpperTriangular starts a n x n upper triangular matrix with
+// the n(n+1)/2 elements e of the upper triangle, row by row. An
+// error is generated if the number of elements does not match.
+func StartUpperTriangular(n int, e ...float64) (UpperTriangular, error) {
+	t, err := startTriangular(n, true, e)
+
+	return UpperTriangular{t}, err
+}
+
+// Set changes the element [r][c] to v. An error is generated if
+// the element does not exist or if it is below the diagonal and v
+// is not zero.
+func (m UpperTriangular) Set(r, c int, v float64) error {
+	return m.set(r, c, v, c >= r, "upper")
+}
+
+// T returns the transpose, a LowerTriangular matrix.
+func (m UpperTriangular) T() Mat {
+	return LowerTriangular{triangular{m.m.Transpose()}}
+}
+
+// LowerTriangular is a square matrix whose elements above the main
+// diagonal are zeros.
+type LowerTriangular struct {
+	triangular
+}
+
+// StartLowerTriangular starts a n x n lower triangular matrix with
+// the n(n+1)/2 elements e of the lower triangle, row by row. An
+// error is generated if the number of elements does not match.
+func StartLowerTriangular(n int, e ...float64) (LowerTriangular, error) {
+	t, err := startTriangular(n, false, e)
+
+	return LowerTriangular{t}, err
+}
+
+// Set changes the element [r][c] to v. An error is generated if
+// the element does not exist or if it is above the diagonal and v
+// is not zero.
+func (m LowerTriangular) Set(r, c int, v float64) error {
+	return m.set(r, c, v, c <= r, "lower")
+}
+
+// T returns the transpose, an UpperTriangular matrix.
+func (m LowerTriangular) T() Mat {
+	return UpperTriangular{triangular{m.m.Transpose()}}
+}
+
+// Symmetric is a square matrix equal to its transpose. Setting the
+// element [r][c] also sets the element [c][r].
+type Symmetric struct {
+	m Matrix
+}
+
+// StartSymmetric starts a n x n symmetric matrix with the n(n+1)/2
+// elements e of the upper triangle, row by row. An error is
+// generated if the number of elements does not match.
+func StartSymmetric(n int, e ...float64) (Symmetric, error) {
+	t, err := startTriangular(n, true, e)
+	if err != nil {
+		return Symmetric{}, err
+	}
+	for r := 0; r < n; r++ {
+		for c := r + 1; c < n; c++ {
+			t.m.elems[c*n+r] = t.m.elems[r*n+c]
+		}
+	}
+
+	return Symmetric{t.m}, nil
+}
+
+// Dims returns the number of rows and columns of the matrix.
+func (m Symmetric) Dims() (int, int) {
+	return m.m.rows, m.m.cols
+}
+
+// At returns the element [r][c]. It panics if the element does
+// not exist.
+func (m Symmetric) At(r, c int) float64 {
+	return m.m.At(r, c)
+}
+
+// Set changes the elements [r][c] and [c][r] to v. An error is
+// generated if the element does not exist.
+func (m Symmetric) Set(r, c int, v float64) error {
+	if err := m.m.SetElement(r, c, v); err != nil {
+		return err
+	}
+
+	return m.m.SetElement(c, r, v)
+}
+
+// T returns the transpose, which is the matrix itself.
+func (m Symmetric) T() Mat {
+	return m
+}
+
+/*
+Banded is a matrix whose nonzero elements are within kl diagonals
+below and ku diagonals above the main diagonal. Only the band is
+stored, kl+ku+1 elements per row, so the memory used grows with
+the number of rows and not with its square.
+*/
+type Banded struct {
+	rows int
+	cols int
+	kl   int
+	ku   int
+	data []float64
+}
+
+// StartBanded starts a r x c banded matrix with zero elements, kl
+// sub-diagonals and ku super-diagonals. An error is generated if
+// the dimensions, kl or ku are negative.
+func StartBanded(r, c, kl, ku int) (Banded, error) {
+	if err := checkDims(r, c); err != nil {
+		return Banded{}, err
+	}
+	if kl < 0 || ku < 0 {
+		return Banded{}, fmt.Errorf("the band widths (%d, %d) must not be negative", kl, ku)
+	}
+
+	return Banded{
+		rows: r,
+		cols: c,
+		kl:   kl,
+		ku:   ku,
+		data: make([]float64, r*(kl+ku+1)),
+	}, nil
+}
+
+// Dims returns the number of rows and columns of the matrix.
+func (m Banded) Dims() (int, int) {
+	return m.rows, m.cols
+}
+
+// Bandwidth returns the number of sub-diagonals, kl, and of
+// super-diagonals, ku, of the band.
+func (m Banded) Bandwidth() (kl, ku int) {
+	return m.kl, m.ku
+}
+
+// inBand returns true if the element [r][c] is inside the band.
+func (m Banded) inBand(r, c int) bool {
+	return c >= r-m.kl && c <= r+m.ku
+}
+
+// index returns the position of the element [r][c], inside the
+// band, in the compact storage.
+func (m Banded) index(r, c int) int {
+	return r*(m.kl+m.ku+1) + c - r + m.kl
+}
+
+// At returns the element [r][c]. It panics if the element does
+// not exist.
+func (m Banded) At(r, c int) float64 {
+	if r < 0 || r >= m.rows || c < 0 || c >= m.cols {
+		panic(outOfRange(r, c).Error())
+	}
+	if !m.inBand(r, c) {
+		return 0.
+	}
+
+	return m.data[m.index(r, c)]
+}
+
+// Set changes the element [r][c] to v. An error is generated if
+// the element does not exist or if it is out of the band and v is
+// not zero.
+func (m Banded) Set(r, c int, v float64) error {
+	if r < 0 || r >= m.rows || c < 0 || c >= m.cols {
+		return outOfRange(r, c)
+	}
+	if !m.inBand(r, c) {
+		if v != 0. {
+			return fmt.Errorf("element [%d][%d] is out of the band", r, c)
+		}
+		return nil
+	}
+	m.data[m.index(r, c)] = v
+
+	return nil
+}
+
+// T returns the transpose, a banded matrix with the widths of the
+// band swapped.
+func (m Banded) T() Mat {
+	t, _ := StartBanded(m.cols, m.rows, m.ku, m.kl)
+	for r := 0; r < m.rows; r++ {
+		for c := max(0, r-m.kl); c <= min(m.cols-1, r+m.ku); c++ {
+			t.data[t.index(c, r)] = m.data[m.index(r, c)]
+		}
+	}
+
+	return t
+}
+
+// Det returns the determinant of the banded matrix, computed by
+// its banded LU decomposition. A NotSquareError is returned if it
+// is not square.
+func (m Banded) Det() (float64, error) {
+	f, err := m.LU()
+	if err != nil {
+		return 0., err
+	}
+
+	return f.Det(), nil
+}
@@ -0,0 +1,184 @@
+package cmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMatInterface(t *testing.T) {
+	m, _ := StartMatrix(2, 2, 1, 2, 3, 4)
+	up, _ := StartUpperTriangular(3, 1, 2, 3, 4, 5, 6)
+	lo, _ := StartLowerTriangular(2, 1, 2, 3)
+	sym, _ := StartSymmetric(2, 1, 2, 3)
+	band, _ := StartBanded(4, 4, 1, 1)
+
+	mats := []Mat{m, StartDiagonal(1, 2, 3), up, lo, sym, band}
+	for _, a := range mats {
+		r, c := a.Dims()
+		tr := a.T()
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				if a.At(i, j) != tr.At(j, i) {
+					t.Fatalf("incorrect result: %T transpose differs at [%d][%d].", a, i, j)
+				}
+			}
+		}
+		if err := a.Set(r, 0, 1); err == nil {
+			t.Errorf("incorrect result: %T expected error, element out of range.", a)
+		}
+	}
+}
+
+func TestStructuredSet(t *testing.T) {
+	d := StartDiagonal(1, 2, 3)
+	if err := d.Set(0, 1, 5); err == nil {
+		t.Error("incorrect result: expected error, off-diagonal element.")
+	}
+	if err := d.Set(1, 1, 5); err != nil || d.At(1, 1) != 5. {
+		t.Error("incorrect result: expected d[1][1] = 5.")
+	}
+
+	up, _ := StartUpperTriangular(3, 1, 2, 3, 4, 5, 6)
+	ans, _ := StartMatrix(3, 3, 1, 2, 3, 0, 4, 5, 0, 0, 6)
+	if !ans.IsEqual(ToDense(up)) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, ToDense(up))
+	}
+	if err := up.Set(2, 0, 1); err == nil {
+		t.Error("incorrect result: expected error, element below the diagonal.")
+	}
+	if err := up.Set(2, 0, 0); err != nil {
+		t.Error("incorrect result: expected err is nil, zero below the diagonal.")
+	}
+
+	lo, _ := StartLowerTriangular(3, 1, 2, 3, 4, 5, 6)
+	ans, _ = StartMatrix(3, 3, 1, 0, 0, 2, 3, 0, 4, 5, 6)
+	if !ans.IsEqual(ToDense(lo)) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, ToDense(lo))
+	}
+	if err := lo.Set(0, 2, 1); err == nil {
+		t.Error("incorrect result: expected error, element above the diagonal.")
+	}
+	if _, err := StartLowerTriangular(3, 1, 2); err == nil {
+		t.Error("incorrect result: expected error, wrong number of elements.")
+	}
+
+	sym, _ := StartSymmetric(2, 1, 2, 3)
+	sym.Set(0, 1, 7)
+	if sym.At(1, 0) != 7. {
+		t.Errorf("incorrect result: expected sym[1][0] = 7, got %v.", sym.At(1, 0))
+	}
+
+	band, _ := StartBanded(4, 4, 1, 0)
+	if err := band.Set(0, 1, 1); err == nil {
+		t.Error("incorrect result: expected error, element out of the band.")
+	}
+	band.Set(3, 2, 9)
+	if band.At(3, 2) != 9. || band.At(0, 3) != 0. {
+		t.Error("incorrect result: expected band[3][2] = 9 and band[0][3] = 0.")
+	}
+}
+
+func TestStructuredDet(t *testing.T) {
+	up, _ := StartUpperTriangular(3, 2, 9, 9, 3, 9, 4)
+	lo, _ := StartLowerTriangular(3, 2, 9, 3, 9, 9, 4)
+	band, _ := StartBanded(3, 3, 1, 1)
+	for i := 0; i < 3; i++ {
+		band.Set(i, i, 2)
+		if i > 0 {
+			band.Set(i, i-1, -1)
+			band.Set(i-1, i, -1)
+		}
+	}
+
+	tests := []struct {
+		a   Mat
+		ans float64
+	}{
+		{StartDiagonal(2, 3, 4), 24},
+		{up, 24},
+		{lo, 24},
+		{band, 4},
+		{must(StartMatrix(2, 2, 1, 2, 3, 4)), -2},
+	}
+	for _, test := range tests {
+		d, err := Det(test.a)
+		if err != nil {
+			t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+		}
+		if math.Abs(d-test.ans) > 1e-12 {
+			t.Errorf("incorrect result: %T expected det %v, got %v.", test.a, test.ans, d)
+		}
+	}
+}
+
+func TestMatProduct(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	up, _ := StartUpperTriangular(3, 1, 2, 3, 4, 5, 6)
+	lo, _ := StartLowerTriangular(3, 1, 2, 3, 4, 5, 6)
+	sym, _ := StartSymmetric(3, 1, 2, 3, 4, 5, 6)
+	band, _ := StartBanded(3, 3, 1, 0)
+	band.Set(0, 0, 1)
+	band.Set(1, 0, 2)
+	band.Set(1, 1, 3)
+	band.Set(2, 1, 4)
+	band.Set(2, 2, 5)
+
+	mats := []Mat{m, StartDiagonal(2, -1, 3), up, lo, sym, band}
+	for _, a := range mats {
+		for _, b := range mats {
+			result, err := Product(a, b)
+			if err != nil {
+				t.Fatal("incorrect result: expected err is nil.")
+			}
+			ans, _ := ToDense(a).Product(ToDense(b))
+			if !ans.IsEqual(result) {
+				t.Fatalf("incorrect result: %T * %T expected \n%v, got\n%v.", a, b, ans, result)
+			}
+		}
+	}
+
+	// The dense operands must not be changed by the diagonal fast path.
+	orig, _ := StartMatrix(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	if !orig.IsEqual(m) {
+		t.Errorf("incorrect result: operand changed to\n%v.", m)
+	}
+
+	rect := StartZerosMatrix(2, 2)
+	if _, err := Product(up, rect); !errors.Is(err, ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+}
+
+func TestMatProductPointerOperand(t *testing.T) {
+	m, _ := StartMatrix(2, 2, 1, 2, 3, 4)
+	orig, _ := StartMatrix(2, 2, 1, 2, 3, 4)
+	d := StartDiagonal(10, 100)
+
+	left, _ := Product(d, &m)
+	right, _ := Product(&m, d)
+	if !orig.IsEqual(m) {
+		t.Fatalf("incorrect result: operand changed to\n%v.", m)
+	}
+
+	ans, _ := StartMatrix(2, 2, 10, 20, 300, 400)
+	if !ans.IsEqual(left) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, left)
+	}
+	ans, _ = StartMatrix(2, 2, 10, 200, 30, 400)
+	if !ans.IsEqual(right) {
+		t.Errorf("incorrect result: expected \n%v, got\n%v.", ans, right)
+	}
+}
+
+func TestStructuredNegativeDims(t *testing.T) {
+	if _, err := StartBanded(-1, 2, 0, 0); err == nil {
+		t.Error("incorrect result: expected error, negative dimensions.")
+	}
+	if _, err := StartUpperTriangular(-1); err == nil {
+		t.Error("incorrect result: expected error, negative dimensions.")
+	}
+	if _, err := StartLowerTriangular(-2); err == nil {
+		t.Error("incorrect result: expected error, negative dimensions.")
+	}
+}
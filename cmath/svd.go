@@ -0,0 +1,563 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package cmath
+
+import (
+	"math"
+)
+
+/*
+SVD holds the singular value decomposition A = U*Σ*Vᵀ of a
+rows x cols matrix A, with k = min(rows, cols). U is a rows x k
+matrix and V a cols x k matrix, both with orthonormal columns,
+and Σ is the diagonal matrix with the singular values S, sorted
+in descending order.
+
+The singular values measure how close A is to a matrix of lower
+rank, so the decomposition answers the questions about the rank,
+the condition number, the fundamental subspaces and the
+pseudo-inverse of A.
+*/
+type SVD struct {
+	U    Matrix
+	S    []float64
+	V    Matrix
+	rows int
+	cols int
+}
+
+// SVD returns the singular value decomposition of the current
+// matrix, m, computed by the Golub-Kahan bidiagonalization followed
+// by implicit shifted QR iterations. A ConvergenceError is returned
+// if the iterations do not converge.
+func (m Matrix) SVD() (SVD, error) {
+	if m.rows < m.cols {
+		// The algorithm needs rows >= cols, so the transpose is
+		// decomposed: Aᵀ = U*Σ*Vᵀ implies A = V*Σ*Uᵀ.
+		t, err := m.Transpose().SVD()
+		if err != nil {
+			return SVD{}, err
+		}
+		return SVD{U: t.V, S: t.S, V: t.U, rows: m.rows, cols: m.cols}, nil
+	}
+
+	u, s, v, err := golubKahan(m.copyElems(), m.rows, m.cols)
+	if err != nil {
+		return SVD{}, err
+	}
+
+	result := SVD{
+		U:    StartZerosMatrix(m.rows, m.cols),
+		S:    s,
+		V:    StartZerosMatrix(m.cols, m.cols),
+		rows: m.rows,
+		cols: m.cols,
+	}
+	for r := 0; r < m.rows; r++ {
+		copy(result.U.row(r), u[r])
+	}
+	for r := 0; r < m.cols; r++ {
+		copy(result.V.row(r), v[r])
+	}
+
+	return result, nil
+}
+
+// tolerance returns tol if it is positive, or the default limit
+// for negligible singular values, max(rows, cols)*σmax*ε.
+func (d SVD) tolerance(tol float64) float64 {
+	if tol > 0 {
+		return tol
+	}
+	if len(d.S) == 0 {
+		return 0.
+	}
+
+	return float64(max(d.rows, d.cols)) * d.S[0] * epsilon
+}
+
+// Rank returns the numerical rank of the decomposed matrix, the
+// number of singular values greater than tol. If tol <= 0 the
+// default max(rows, cols)*σmax*ε is used.
+func (d SVD) Rank(tol float64) int {
+	tol = d.tolerance(tol)
+	rank := 0
+	for _, s := range d.S {
+		if s > tol {
+			rank++
+		}
+	}
+
+	return rank
+}
+
+// Cond returns the 2-norm condition number of the decomposed
+// matrix, the ratio between the largest and the smallest singular
+// values. It is +Inf for rank deficient matrices.
+func (d SVD) Cond() float64 {
+	if len(d.S) == 0 {
+		return 0.
+	}
+
+	smin := d.S[len(d.S)-1]
+	if smin == 0. {
+		return math.Inf(1)
+	}
+
+	return d.S[0] / smin
+}
+
+// PseudoInverse returns the Moore-Penrose pseudo-inverse of the
+// decomposed matrix, V*Σ⁺*Uᵀ, where Σ⁺ inverts the singular values
+// greater than tol and zeros the others. If tol <= 0 the default
+// of Rank is used.
+func (d SVD) PseudoInverse(tol float64) Matrix {
+	tol = d.tolerance(tol)
+	result := StartZerosMatrix(d.cols, d.rows)
+
+	for k, s := range d.S {
+		if s <= tol {
+			continue
+		}
+		for r := 0; r < d.cols; r++ {
+			f := d.V.at(r, k) / s
+			for c := 0; c < d.rows; c++ {
+				result.elems[r*result.stride+c] += f * d.U.at(c, k)
+			}
+		}
+	}
+
+	return result
+}
+
+// ColumnSpace returns a matrix whose columns are an orthonormal
+// basis of the column space (range) of the decomposed matrix. The
+// tol parameter has the same meaning as in Rank.
+func (d SVD) ColumnSpace(tol float64) Matrix {
+	rank := d.Rank(tol)
+	result := StartZerosMatrix(d.rows, rank)
+
+	for r := 0; r < d.rows; r++ {
+		copy(result.row(r), d.U.row(r)[:rank])
+	}
+
+	return result
+}
+
+// NullSpace returns a matrix whose columns are an orthonormal basis
+// of the null space (kernel) of the decomposed matrix, the vectors
+// x with A*x = 0. The tol parameter has the same meaning as in
+// Rank. The result has cols - rank columns.
+func (d SVD) NullSpace(tol float64) Matrix {
+	rank := d.Rank(tol)
+
+	// The null space is the orthogonal complement of the first rank
+	// right singular vectors. It is found from the full Q factor of
+	// their QR decomposition, padded with zero columns.
+	basis := StartZerosMatrix(d.cols, d.cols)
+	for r := 0; r < d.cols; r++ {
+		copy(basis.row(r), d.V.row(r)[:rank])
+	}
+	qr, _ := basis.QR()
+	q := qr.Q()
+
+	result := StartZerosMatrix(d.cols, d.cols-rank)
+	for r := 0; r < d.cols; r++ {
+		copy(result.row(r), q.row(r)[rank:])
+	}
+
+	return result
+}
+
+// Rank returns the numerical rank of the current matrix, m, with
+// the same tolerance rules of SVD.Rank.
+func (m Matrix) Rank(tol float64) (int, error) {
+	svd, err := m.SVD()
+	if err != nil {
+		return 0, err
+	}
+
+	return svd.Rank(tol), nil
+}
+
+// Cond returns the 2-norm condition number of the current matrix,
+// m.
+func (m Matrix) Cond() (float64, error) {
+	svd, err := m.SVD()
+	if err != nil {
+		return 0., err
+	}
+
+	return svd.Cond(), nil
+}
+
+// PseudoInverse returns the Moore-Penrose pseudo-inverse of the
+// current matrix, m, with the default tolerance of SVD.Rank.
+func (m Matrix) PseudoInverse() (Matrix, error) {
+	svd, err := m.SVD()
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	return svd.PseudoInverse(0.), nil
+}
+
+// golubKahan computes the singular value decomposition of the
+// rows x cols matrix a, with rows >= cols, and returns the factors
+// U (rows x cols), the singular values and V (cols x cols). The
+// matrix a is overwritten.
+//
+// The algorithm is a translation of the LINPACK routine dsvdc, as
+// found in the JAMA package.
+func golubKahan(a [][]float64, m, n int) (u [][]float64, s []float64, v [][]float64, err error) {
+	const tiny = 0x1p-966
+
+	nu := min(m, n)
+	s = make([]float64, min(m+1, n))
+	u = make([][]float64, m)
+	for i := range u {
+		u[i] = make([]float64, nu)
+	}
+	v = make([][]float64, n)
+	for i := range v {
+		v[i] = make([]float64, n)
+	}
+	e := make([]float64, n)
+	work := make([]float64, m)
+
+	// Reduce a to bidiagonal form, storing the diagonal elements
+	// in s and the super-diagonal elements in e.
+	nct := min(m-1, n)
+	nrt := max(0, min(n-2, m))
+	for k := 0; k < max(nct, nrt); k++ {
+		if k < nct {
+			// Compute the transformation for the k-th column.
+			s[k] = 0.
+			for i := k; i < m; i++ {
+				s[k] = math.Hypot(s[k], a[i][k])
+			}
+			if s[k] != 0. {
+				if a[k][k] < 0. {
+					s[k] = -s[k]
+				}
+				for i := k; i < m; i++ {
+					a[i][k] /= s[k]
+				}
+				a[k][k] += 1.
+			}
+			s[k] = -s[k]
+		}
+		for j := k + 1; j < n; j++ {
+			if k < nct && s[k] != 0. {
+				// Apply the transformation.
+				t := 0.
+				for i := k; i < m; i++ {
+					t += a[i][k] * a[i][j]
+				}
+				t = -t / a[k][k]
+				for i := k; i < m; i++ {
+					a[i][j] += t * a[i][k]
+				}
+			}
+			// Place the k-th row of a into e for the subsequent
+			// calculation of the row transformation.
+			e[j] = a[k][j]
+		}
+		if k < nct {
+			for i := k; i < m; i++ {
+				u[i][k] = a[i][k]
+			}
+		}
+		if k < nrt {
+			// Compute the k-th row transformation.
+			e[k] = 0.
+			for i := k + 1; i < n; i++ {
+				e[k] = math.Hypot(e[k], e[i])
+			}
+			if e[k] != 0. {
+				if e[k+1] < 0. {
+					e[k] = -e[k]
+				}
+				for i := k + 1; i < n; i++ {
+					e[i] /= e[k]
+				}
+				e[k+1] += 1.
+			}
+			e[k] = -e[k]
+			if k+1 < m && e[k] != 0. {
+				// Apply the transformation.
+				for i := k + 1; i < m; i++ {
+					work[i] = 0.
+				}
+				for j := k + 1; j < n; j++ {
+					for i := k + 1; i < m; i++ {
+						work[i] += e[j] * a[i][j]
+					}
+				}
+				for j := k + 1; j < n; j++ {
+					t := -e[j] / e[k+1]
+					for i := k + 1; i < m; i++ {
+						a[i][j] += t * work[i]
+					}
+				}
+			}
+			for i := k + 1; i < n; i++ {
+				v[i][k] = e[i]
+			}
+		}
+	}
+
+	// Set up the final bidiagonal matrix of order p.
+	p := min(n, m+1)
+	if nct < n {
+		s[nct] = a[nct][nct]
+	}
+	if m < p {
+		s[p-1] = 0.
+	}
+	if nrt+1 < p {
+		e[nrt] = a[nrt][p-1]
+	}
+	e[p-1] = 0.
+
+	// Generate U.
+	for j := nct; j < nu; j++ {
+		for i := 0; i < m; i++ {
+			u[i][j] = 0.
+		}
+		u[j][j] = 1.
+	}
+	for k := nct - 1; k >= 0; k-- {
+		if s[k] == 0. {
+			for i := 0; i < m; i++ {
+				u[i][k] = 0.
+			}
+			u[k][k] = 1.
+			continue
+		}
+		for j := k + 1; j < nu; j++ {
+			t := 0.
+			for i := k; i < m; i++ {
+				t += u[i][k] * u[i][j]
+			}
+			t = -t / u[k][k]
+			for i := k; i < m; i++ {
+				u[i][j] += t * u[i][k]
+			}
+		}
+		for i := k; i < m; i++ {
+			u[i][k] = -u[i][k]
+		}
+		u[k][k] += 1.
+		for i := 0; i < k; i++ {
+			u[i][k] = 0.
+		}
+	}
+
+	// Generate V.
+	for k := n - 1; k >= 0; k-- {
+		if k < nrt && e[k] != 0. {
+			for j := k + 1; j < nu; j++ {
+				t := 0.
+				for i := k + 1; i < n; i++ {
+					t += v[i][k] * v[i][j]
+				}
+				t = -t / v[k+1][k]
+				for i := k + 1; i < n; i++ {
+					v[i][j] += t * v[i][k]
+				}
+			}
+		}
+		for i := 0; i < n; i++ {
+			v[i][k] = 0.
+		}
+		v[k][k] = 1.
+	}
+
+	// Main iteration loop for the singular values.
+	pp := p - 1
+	maxIter := 75 * max(n, 10)
+	total := 0
+	for p > 0 {
+		// Inspect for negligible elements in the s and e arrays.
+		// On completion the variables kase and k are set as:
+		//  kase = 1: if s[p] and e[k-1] are negligible and k < p
+		//  kase = 2: if s[k] is negligible and k < p
+		//  kase = 3: if e[k-1] is negligible, k < p, and
+		//            s[k], ..., s[p] are not negligible (QR step).
+		//  kase = 4: if e[p-1] is negligible (convergence).
+		var k, kase int
+		for k = p - 2; k >= 0; k-- {
+			if math.Abs(e[k]) <= tiny+epsilon*(math.Abs(s[k])+math.Abs(s[k+1])) {
+				e[k] = 0.
+				break
+			}
+		}
+		if k == p-2 {
+			kase = 4
+		} else {
+			var ks int
+			for ks = p - 1; ks > k; ks-- {
+				t := 0.
+				if ks != p {
+					t += math.Abs(e[ks])
+				}
+				if ks != k+1 {
+					t += math.Abs(e[ks-1])
+				}
+				if math.Abs(s[ks]) <= tiny+epsilon*t {
+					s[ks] = 0.
+					break
+				}
+			}
+			switch {
+			case ks == k:
+				kase = 3
+			case ks == p-1:
+				kase = 1
+			default:
+				kase = 2
+				k = ks
+			}
+		}
+		k++
+
+		switch kase {
+		case 1:
+			// Deflate negligible s[p].
+			f := e[p-2]
+			e[p-2] = 0.
+			for j := p - 2; j >= k; j-- {
+				t := math.Hypot(s[j], f)
+				cs := s[j] / t
+				sn := f / t
+				s[j] = t
+				if j != k {
+					f = -sn * e[j-1]
+					e[j-1] = cs * e[j-1]
+				}
+				for i := 0; i < n; i++ {
+					t = cs*v[i][j] + sn*v[i][p-1]
+					v[i][p-1] = -sn*v[i][j] + cs*v[i][p-1]
+					v[i][j] = t
+				}
+			}
+
+		case 2:
+			// Split at negligible s[k].
+			f := e[k-1]
+			e[k-1] = 0.
+			for j := k; j < p; j++ {
+				t := math.Hypot(s[j], f)
+				cs := s[j] / t
+				sn := f / t
+				s[j] = t
+				f = -sn * e[j]
+				e[j] = cs * e[j]
+				for i := 0; i < m; i++ {
+					t = cs*u[i][j] + sn*u[i][k-1]
+					u[i][k-1] = -sn*u[i][j] + cs*u[i][k-1]
+					u[i][j] = t
+				}
+			}
+
+		case 3:
+			// Perform one QR step.
+			total++
+			if total > maxIter {
+				return nil, nil, nil, ConvergenceError{"SVD QR iteration", maxIter}
+			}
+
+			// Calculate the shift.
+			scale := math.Max(math.Max(math.Max(math.Max(
+				math.Abs(s[p-1]), math.Abs(s[p-2])), math.Abs(e[p-2])),
+				math.Abs(s[k])), math.Abs(e[k]))
+			sp := s[p-1] / scale
+			spm1 := s[p-2] / scale
+			epm1 := e[p-2] / scale
+			sk := s[k] / scale
+			ek := e[k] / scale
+			b := ((spm1+sp)*(spm1-sp) + epm1*epm1) / 2.
+			c := (sp * epm1) * (sp * epm1)
+			shift := 0.
+			if b != 0. || c != 0. {
+				shift = math.Sqrt(b*b + c)
+				if b < 0. {
+					shift = -shift
+				}
+				shift = c / (b + shift)
+			}
+			f := (sk+sp)*(sk-sp) + shift
+			g := sk * ek
+
+			// Chase zeros.
+			for j := k; j < p-1; j++ {
+				t := math.Hypot(f, g)
+				cs := f / t
+				sn := g / t
+				if j != k {
+					e[j-1] = t
+				}
+				f = cs*s[j] + sn*e[j]
+				e[j] = cs*e[j] - sn*s[j]
+				g = sn * s[j+1]
+				s[j+1] = cs * s[j+1]
+				for i := 0; i < n; i++ {
+					t = cs*v[i][j] + sn*v[i][j+1]
+					v[i][j+1] = -sn*v[i][j] + cs*v[i][j+1]
+					v[i][j] = t
+				}
+				t = math.Hypot(f, g)
+				cs = f / t
+				sn = g / t
+				s[j] = t
+				f = cs*e[j] + sn*s[j+1]
+				s[j+1] = -sn*e[j] + cs*s[j+1]
+				g = sn * e[j+1]
+				e[j+1] = cs * e[j+1]
+				if j < m-1 {
+					for i := 0; i < m; i++ {
+						t = cs*u[i][j] + sn*u[i][j+1]
+						u[i][j+1] = -sn*u[i][j] + cs*u[i][j+1]
+						u[i][j] = t
+					}
+				}
+			}
+			e[p-2] = f
+
+		case 4:
+			// Convergence. Make the singular value positive.
+			if s[k] <= 0. {
+				s[k] = math.Abs(s[k])
+				for i := 0; i <= pp; i++ {
+					v[i][k] = -v[i][k]
+				}
+			}
+			// Order the singular values.
+			for k < pp {
+				if s[k] >= s[k+1] {
+					break
+				}
+				s[k], s[k+1] = s[k+1], s[k]
+				if k < n-1 {
+					for i := 0; i < n; i++ {
+						v[i][k], v[i][k+1] = v[i][k+1], v[i][k]
+					}
+				}
+				if k < m-1 {
+					for i := 0; i < m; i++ {
+						u[i][k], u[i][k+1] = u[i][k+1], u[i][k]
+					}
+				}
+				k++
+			}
+			p--
+		}
+	}
+
+	return u, s[:nu], v, nil
+}
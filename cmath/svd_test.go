@@ -0,0 +1,159 @@
+package cmath
+
+import (
+	"math"
+	"testing"
+)
+
+// checkSVD verifies that U*Σ*Vᵀ rebuilds m, that U and V have
+// orthonormal columns and that the singular values are sorted.
+func checkSVD(t *testing.T, m Matrix, svd SVD) {
+	t.Helper()
+	k := len(svd.S)
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			e := 0.
+			for i := 0; i < k; i++ {
+				e += svd.U.at(r, i) * svd.S[i] * svd.V.at(c, i)
+			}
+			if math.Abs(e-m.at(r, c)) > 1e-10 {
+				t.Fatalf("incorrect result: expected U*Σ*Vᵀ = \n%v, got %v at [%d][%d].", m, e, r, c)
+			}
+		}
+	}
+	for _, q := range []Matrix{svd.U, svd.V} {
+		for i := 0; i < k; i++ {
+			for j := 0; j < k; j++ {
+				e := 0.
+				for r := 0; r < q.rows; r++ {
+					e += q.at(r, i) * q.at(r, j)
+				}
+				if i == j {
+					e -= 1.
+				}
+				if math.Abs(e) > 1e-10 {
+					t.Fatalf("incorrect result: columns of\n%v are not orthonormal.", q)
+				}
+			}
+		}
+	}
+	for i := 1; i < k; i++ {
+		if svd.S[i] > svd.S[i-1] || svd.S[i] < 0 {
+			t.Fatalf("incorrect result: singular values not sorted %v.", svd.S)
+		}
+	}
+}
+
+func TestSVD(t *testing.T) {
+	tests := []Matrix{
+		must(StartMatrix(2, 2, 3, 0, 0, -4)),
+		must(StartMatrix(3, 2, 1, 2, 3, 4, 5, 6)),
+		must(StartMatrix(2, 3, 1, 2, 3, 4, 5, 6)),
+		must(StartMatrix(4, 4, 2, -1, 0, 0, -1, 2, -1, 0, 0, -1, 2, -1, 0, 0, -1, 2)),
+		must(StartMatrix(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)),
+		must(StartMatrix(3, 4, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0)),
+	}
+	for _, m := range tests {
+		svd, err := m.SVD()
+		if err != nil {
+			t.Fatalf("incorrect result: expected err is nil, got %v.", err)
+		}
+		checkSVD(t, m, svd)
+	}
+
+	m, _ := StartMatrix(2, 2, 3, 0, 0, -4)
+	svd, _ := m.SVD()
+	if svd.S[0] != 4. || svd.S[1] != 3. {
+		t.Errorf("incorrect result: expected [4 3], got %v.", svd.S)
+	}
+}
+
+func TestRankCond(t *testing.T) {
+	m, _ := StartMatrix(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	if r, _ := m.Rank(0); r != 2 {
+		t.Errorf("incorrect result: expected rank 2, got %d.", r)
+	}
+	if c, _ := m.Cond(); c < 1e15 {
+		t.Errorf("incorrect result: expected huge condition number, got %v.", c)
+	}
+
+	m, _ = StartMatrix(2, 3, 1, 2, 3, 2, 4, 6)
+	if r, _ := m.Rank(0); r != 1 {
+		t.Errorf("incorrect result: expected rank 1, got %d.", r)
+	}
+
+	m, _ = StartMatrix(2, 2, 3, 0, 0, -4)
+	if c, _ := m.Cond(); math.Abs(c-4./3.) > 1e-15 {
+		t.Errorf("incorrect result: expected cond %v, got %v.", 4./3., c)
+	}
+	if r, _ := m.Rank(0); r != 2 {
+		t.Errorf("incorrect result: expected rank 2, got %d.", r)
+	}
+}
+
+func TestPseudoInverse(t *testing.T) {
+	// For a full rank square matrix the pseudo-inverse is the inverse.
+	m, _ := StartMatrix(2, 2, 4, 7, 2, 6)
+	pinv, err := m.PseudoInverse()
+	if err != nil {
+		t.Fatal("incorrect result: expected err is nil.")
+	}
+	inv, _ := m.Inverse()
+	for r := 0; r < 2; r++ {
+		for c := 0; c < 2; c++ {
+			if math.Abs(pinv.at(r, c)-inv.at(r, c)) > 1e-12 {
+				t.Fatalf("incorrect result: expected \n%v, got\n%v.", inv, pinv)
+			}
+		}
+	}
+
+	// Moore-Penrose conditions A*A⁺*A = A for a rank deficient matrix.
+	m, _ = StartMatrix(3, 2, 1, 2, 2, 4, 3, 6)
+	pinv, _ = m.PseudoInverse()
+	if pinv.rows != 2 || pinv.cols != 3 {
+		t.Fatalf("incorrect result: expected 2x3 matrix, got %dx%d.", pinv.rows, pinv.cols)
+	}
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 2; c++ {
+			e := 0.
+			for i := 0; i < 2; i++ {
+				for j := 0; j < 3; j++ {
+					e += m.at(r, i) * pinv.at(i, j) * m.at(j, c)
+				}
+			}
+			if math.Abs(e-m.at(r, c)) > 1e-12 {
+				t.Fatalf("incorrect result: A*A⁺*A != A at [%d][%d].", r, c)
+			}
+		}
+	}
+}
+
+func TestSubspaces(t *testing.T) {
+	m, _ := StartMatrix(2, 3, 1, 2, 3, 2, 4, 6)
+	svd, _ := m.SVD()
+
+	null := svd.NullSpace(0)
+	if null.rows != 3 || null.cols != 2 {
+		t.Fatalf("incorrect result: expected 3x2 null space, got %dx%d.", null.rows, null.cols)
+	}
+	for k := 0; k < null.cols; k++ {
+		for r := 0; r < m.rows; r++ {
+			e := 0.
+			for c := 0; c < m.cols; c++ {
+				e += m.at(r, c) * null.at(c, k)
+			}
+			if math.Abs(e) > 1e-12 {
+				t.Fatalf("incorrect result: A*x != 0 for null space vector %d.", k)
+			}
+		}
+	}
+
+	col := svd.ColumnSpace(0)
+	if col.rows != 2 || col.cols != 1 {
+		t.Fatalf("incorrect result: expected 2x1 column space, got %dx%d.", col.rows, col.cols)
+	}
+	// The column space is spanned by (1, 2).
+	if math.Abs(col.at(0, 0)*2-col.at(1, 0)) > 1e-12 {
+		t.Errorf("incorrect result: expected column space parallel to (1, 2), got\n%v.", col)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package csolver
+
+import (
+	"context"
+	"math"
+)
+
+// CG solves A*x = b by the preconditioned conjugate gradient method.
+// A must be symmetric positive-definite, and so must s.Precond, if
+// it is set. A cmath.ConvergenceError is returned, with the partial
+// Result, if the tolerance is not reached, as when p*A*p vanishes
+// for a matrix that is not positive-definite, and ctx.Err() if ctx
+// is canceled.
+func CG(ctx context.Context, a Operator, b []float64, s Settings) (Result, error) {
+	n, c := a.Dims()
+	x, err := prepare(n, c, b, &s)
+	if err != nil {
+		return Result{}, err
+	}
+
+	bnorm := norm2(b)
+	r := make([]float64, n)
+	z := make([]float64, n)
+	p := make([]float64, n)
+	ap := make([]float64, n)
+	res := Result{X: x}
+	res.Residuals = append(res.Residuals, relNorm(residual(a, x, b, r), bnorm))
+
+	applyPrecond(s.Precond, z, r)
+	copy(p, z)
+	rz := dot(r, z)
+
+	for res.Iterations < s.MaxIter && res.Residuals[res.Iterations] > s.Tol {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+
+		a.MulVecTo(ap, p)
+		pap := dot(p, ap)
+		if pap == 0. {
+			break
+		}
+		alpha := rz / pap
+		for i := range x {
+			x[i] += alpha * p[i]
+			r[i] -= alpha * ap[i]
+		}
+		res.Iterations++
+		res.Residuals = append(res.Residuals, relNorm(norm2(r), bnorm))
+
+		applyPrecond(s.Precond, z, r)
+		rzNew := dot(r, z)
+		beta := rzNew / rz
+		rz = rzNew
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+	res.Converged = res.Residuals[res.Iterations] <= s.Tol
+
+	return finish(res, "CG")
+}
+
+// GMRES solves A*x = b by the restarted generalized minimal residual
+// method, GMRES(s.Restart), with right preconditioning by s.Precond,
+// if it is set. It accepts any nonsingular A. A
+// cmath.ConvergenceError is returned, with the partial Result, if
+// the tolerance is not reached or the method stagnates, and
+// ctx.Err() if ctx is canceled. The Result of a canceled run
+// includes the update of its partial cycle.
+func GMRES(ctx context.Context, a Operator, b []float64, s Settings) (Result, error) {
+	n, c := a.Dims()
+	x, err := prepare(n, c, b, &s)
+	if err != nil {
+		return Result{}, err
+	}
+
+	m := s.Restart
+	bnorm := norm2(b)
+	r := make([]float64, n)
+	w := make([]float64, n)
+	z := make([]float64, n)
+	v := make([][]float64, m+1)
+	for i := range v {
+		v[i] = make([]float64, n)
+	}
+	h := make([][]float64, m+1)
+	for i := range h {
+		h[i] = make([]float64, m)
+	}
+	cs := make([]float64, m)
+	sn := make([]float64, m)
+	g := make([]float64, m+1)
+	y := make([]float64, m)
+
+	beta := residual(a, x, b, r)
+	res := Result{X: x}
+	res.Residuals = append(res.Residuals, relNorm(beta, bnorm))
+
+	for res.Iterations < s.MaxIter && res.Residuals[res.Iterations] > s.Tol {
+		// Start a cycle from the current residual.
+		for i := range r {
+			v[0][i] = r[i] / beta
+		}
+		for i := range g {
+			g[i] = 0.
+		}
+		g[0] = beta
+
+		k := 0
+		stagnated := false
+		for k < m && res.Iterations < s.MaxIter {
+			if err = ctx.Err(); err != nil {
+				break
+			}
+
+			// Arnoldi process with modified Gram-Schmidt.
+			applyPrecond(s.Precond, z, v[k])
+			a.MulVecTo(w, z)
+			for i := 0; i <= k; i++ {
+				h[i][k] = dot(w, v[i])
+				for j := range w {
+					w[j] -= h[i][k] * v[i][j]
+				}
+			}
+			h[k+1][k] = norm2(w)
+			if h[k+1][k] != 0. {
+				for j := range w {
+					v[k+1][j] = w[j] / h[k+1][k]
+				}
+			}
+
+			// Apply the previous Givens rotations to the new column
+			// and compute the one that zeros h[k+1][k].
+			for i := 0; i < k; i++ {
+				t := cs[i]*h[i][k] + sn[i]*h[i+1][k]
+				h[i+1][k] = -sn[i]*h[i][k] + cs[i]*h[i+1][k]
+				h[i][k] = t
+			}
+			t := math.Hypot(h[k][k], h[k+1][k])
+			if t == 0. {
+				// The new direction adds nothing to the Krylov space
+				// and GMRES stagnates: A is singular.
+				stagnated = true
+				break
+			}
+			cs[k] = h[k][k] / t
+			sn[k] = h[k+1][k] / t
+			h[k][k] = t
+			h[k+1][k] = 0.
+			g[k+1] = -sn[k] * g[k]
+			g[k] *= cs[k]
+
+			k++
+			res.Iterations++
+			res.Residuals = append(res.Residuals, relNorm(math.Abs(g[k]), bnorm))
+			if res.Residuals[res.Iterations] <= s.Tol {
+				break
+			}
+		}
+
+		// Solve the k x k triangular system H*y = g and update
+		// x ← x + M⁻¹(V*y).
+		for i := k - 1; i >= 0; i-- {
+			y[i] = g[i]
+			for j := i + 1; j < k; j++ {
+				y[i] -= h[i][j] * y[j]
+			}
+			y[i] /= h[i][i]
+		}
+		for j := range w {
+			w[j] = 0.
+		}
+		for i := 0; i < k; i++ {
+			for j := range w {
+				w[j] += y[i] * v[i][j]
+			}
+		}
+		applyPrecond(s.Precond, z, w)
+		for j := range x {
+			x[j] += z[j]
+		}
+
+		// The true residual replaces the estimate of the cycle.
+		beta = residual(a, x, b, r)
+		res.Residuals[res.Iterations] = relNorm(beta, bnorm)
+		if err != nil {
+			return res, err
+		}
+		if stagnated {
+			break
+		}
+	}
+	res.Converged = res.Residuals[res.Iterations] <= s.Tol
+
+	return finish(res, "GMRES")
+}
+
+// applyPrecond stores M⁻¹*r in dst, or copies r if p is nil.
+func applyPrecond(p Preconditioner, dst, r []float64) {
+	if p == nil {
+		copy(dst, r)
+		return
+	}
+	p.Apply(dst, r)
+}
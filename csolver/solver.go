@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+/*
+Package csolver has iterative solvers for large linear systems
+A*x = b, where direct elimination is too slow or uses too much
+memory.
+
+The stationary methods (Jacobi, Gauss-Seidel and SOR) work on a
+cmath.Matrix, while the Krylov methods (CG and GMRES) only need
+the product of A by a vector and accept any Operator, such as
+cmath.Matrix and cmath.CSR.
+*/
+package csolver
+
+import (
+	"fmt"
+	"math"
+
+	"mycodes/calc/cmath"
+)
+
+// Operator is a linear operator A, represented only by its size and
+// the product by a vector. Both cmath.Matrix and cmath.CSR satisfy
+// this interface.
+type Operator interface {
+	Dims() (int, int)
+	MulVecTo(dst, x []float64) error
+}
+
+// Preconditioner approximates the inverse of A. Apply stores in dst
+// the solution z of M*z = r, where M is an approximation of A that
+// is cheap to invert.
+type Preconditioner interface {
+	Apply(dst, r []float64)
+}
+
+// JacobiPrecond is the diagonal (Jacobi) preconditioner, M = diag(A). It
+// holds the inverse of the diagonal elements.
+type JacobiPrecond []float64
+
+// NewJacobiPrecond returns the Jacobi preconditioner of the matrix a. An
+// error is generated if a is not square or has a zero on the
+// diagonal.
+func NewJacobiPrecond(a cmath.Matrix) (JacobiPrecond, error) {
+	n, c := a.Dims()
+	if n != c {
+		return nil, cmath.NotSquareError{Rows: n, Cols: c}
+	}
+
+	p := make(JacobiPrecond, n)
+	for i := range p {
+		d, _ := a.GetElement(i, i)
+		if d == 0. {
+			return nil, fmt.Errorf("zero diagonal element in row %d", i)
+		}
+		p[i] = 1. / d
+	}
+
+	return p, nil
+}
+
+// Apply implements the Preconditioner interface.
+func (p JacobiPrecond) Apply(dst, r []float64) {
+	for i := range dst {
+		dst[i] = p[i] * r[i]
+	}
+}
+
+// Settings holds the parameters of the iterative solvers. The zero
+// value selects the defaults of each field.
+type Settings struct {
+	// Tol is the relative residual |b - A*x|/|b| at which the
+	// iteration stops. The default is 1e-10.
+	Tol float64
+
+	// MaxIter is the maximum number of iterations, the default is
+	// 10 times the order of A.
+	MaxIter int
+
+	// Precond is the preconditioner. It is used by Jacobi, in place
+	// of the diagonal of A, and by CG and GMRES. Gauss-Seidel and SOR
+	// ignore it.
+	Precond Preconditioner
+
+	// Omega is the relaxation factor of SOR, in the interval (0, 2).
+	// The default is 1, which is the Gauss-Seidel method.
+	Omega float64
+
+	// Restart is the number of GMRES iterations between restarts.
+	// The default is min(30, n).
+	Restart int
+
+	// X0 is the initial guess. The default is the zero vector.
+	X0 []float64
+}
+
+// Result is the convergence report of an iterative solver.
+type Result struct {
+	// X is the last approximation of the solution.
+	X []float64
+
+	// Iterations is the number of iterations performed.
+	Iterations int
+
+	// Residuals is the history of the relative residual norms,
+	// starting with the residual of the initial guess.
+	Residuals []float64
+
+	// Converged is true if the residual reached Settings.Tol.
+	Converged bool
+}
+
+// prepare checks the system size, fills the defaults of s and
+// returns the initial guess.
+func prepare(n, c int, b []float64, s *Settings) ([]float64, error) {
+	if n != c {
+		return nil, cmath.NotSquareError{Rows: n, Cols: c}
+	}
+	if len(b) != n {
+		return nil, fmt.Errorf("b has %d elements, expected %d: %w", len(b), n, cmath.ErrDimension)
+	}
+	if s.X0 != nil && len(s.X0) != n {
+		return nil, fmt.Errorf("X0 has %d elements, expected %d: %w", len(s.X0), n, cmath.ErrDimension)
+	}
+
+	if s.Tol <= 0. {
+		s.Tol = 1e-10
+	}
+	if s.MaxIter <= 0 {
+		s.MaxIter = 10 * n
+	}
+	if s.Omega == 0. {
+		s.Omega = 1.
+	}
+	if s.Restart <= 0 {
+		s.Restart = min(30, n)
+	}
+
+	x := make([]float64, n)
+	copy(x, s.X0)
+
+	return x, nil
+}
+
+// residual stores b - A*x in r and returns its norm.
+func residual(a Operator, x, b, r []float64) float64 {
+	a.MulVecTo(r, x)
+	for i := range r {
+		r[i] = b[i] - r[i]
+	}
+
+	return norm2(r)
+}
+
+// finish completes the Result and returns the error for a run that
+// did not converge, with the iterations actually done.
+func finish(res Result, method string) (Result, error) {
+	if res.Converged {
+		return res, nil
+	}
+
+	return res, cmath.ConvergenceError{Method: method, Iterations: res.Iterations}
+}
+
+// dot returns the dot product of the slices x and y.
+func dot(x, y []float64) float64 {
+	s := 0.
+	for i := range x {
+		s += x[i] * y[i]
+	}
+
+	return s
+}
+
+// norm2 returns the Euclidean norm of the slice x.
+func norm2(x []float64) float64 {
+	return math.Sqrt(dot(x, x))
+}
+
+// relNorm returns r/bnorm, or r if b is the zero vector.
+func relNorm(r, bnorm float64) float64 {
+	if bnorm == 0. {
+		return r
+	}
+
+	return r / bnorm
+}
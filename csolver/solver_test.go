@@ -0,0 +1,203 @@
+package csolver
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"mycodes/calc/cmath"
+)
+
+// poisson returns the n x n matrix of the 1D Poisson problem,
+// tridiag(-1, 2, -1), plus shift on the diagonal.
+func poisson(n int, shift float64) cmath.Matrix {
+	a := cmath.StartZerosMatrix(n, n)
+	for i := 0; i < n; i++ {
+		a.SetElement(i, i, 2+shift)
+		if i > 0 {
+			a.SetElement(i, i-1, -1)
+		}
+		if i < n-1 {
+			a.SetElement(i, i+1, -1)
+		}
+	}
+	return a
+}
+
+// system returns b = A*x for a known solution x.
+func system(a cmath.Matrix) ([]float64, []float64) {
+	n, _ := a.Dims()
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Cos(float64(i))
+	}
+	b, _ := a.MulVec(x)
+	return x, b
+}
+
+func checkSolution(t *testing.T, name string, res Result, err error, x []float64, tol float64) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: incorrect result: expected err is nil, got %v.", name, err)
+	}
+	if !res.Converged || len(res.Residuals) != res.Iterations+1 {
+		t.Fatalf("%s: incorrect result: converged %v with %d iterations and %d residuals.",
+			name, res.Converged, res.Iterations, len(res.Residuals))
+	}
+	for i := range x {
+		if math.Abs(res.X[i]-x[i]) > tol {
+			t.Fatalf("%s: incorrect result: x[%d] = %v, expected %v.", name, i, res.X[i], x[i])
+		}
+	}
+}
+
+func TestStationary(t *testing.T) {
+	ctx := context.Background()
+	a := poisson(30, 0.05)
+	x, b := system(a)
+	s := Settings{Tol: 1e-12, MaxIter: 20000}
+
+	res, err := Jacobi(ctx, a, b, s)
+	checkSolution(t, "Jacobi", res, err, x, 1e-10)
+	jacobiIter := res.Iterations
+
+	res, err = GaussSeidel(ctx, a, b, s)
+	checkSolution(t, "Gauss-Seidel", res, err, x, 1e-10)
+	if res.Iterations >= jacobiIter {
+		t.Errorf("incorrect result: expected Gauss-Seidel faster than Jacobi, got %d >= %d.", res.Iterations, jacobiIter)
+	}
+	gsIter := res.Iterations
+
+	// The preconditioner is ignored.
+	jac, _ := NewJacobiPrecond(a)
+	res, err = GaussSeidel(ctx, a, b, Settings{Tol: 1e-12, MaxIter: 20000, Precond: jac})
+	checkSolution(t, "Gauss-Seidel", res, err, x, 1e-10)
+	if res.Iterations != gsIter {
+		t.Errorf("incorrect result: expected %d iterations, got %d.", gsIter, res.Iterations)
+	}
+
+	s.Omega = 1.7
+	res, err = SOR(ctx, a, b, s)
+	checkSolution(t, "SOR", res, err, x, 1e-10)
+	if res.Iterations >= gsIter {
+		t.Errorf("incorrect result: expected SOR faster than Gauss-Seidel, got %d >= %d.", res.Iterations, gsIter)
+	}
+
+	s.Omega = 2.5
+	if _, err := SOR(ctx, a, b, s); err == nil {
+		t.Error("incorrect result: expected error, omega outside (0, 2).")
+	}
+}
+
+func TestKrylov(t *testing.T) {
+	ctx := context.Background()
+	a := poisson(200, 0.01)
+	x, b := system(a)
+
+	res, err := CG(ctx, a, b, Settings{Tol: 1e-12})
+	checkSolution(t, "CG", res, err, x, 1e-8)
+
+	jac, _ := NewJacobiPrecond(a)
+	res, err = CG(ctx, a.ToCSR(), b, Settings{Tol: 1e-12, Precond: jac})
+	checkSolution(t, "PCG", res, err, x, 1e-8)
+
+	// Nonsymmetric matrix for GMRES.
+	for i := 0; i < 199; i++ {
+		a.SetElement(i, i+1, -0.5)
+	}
+	x, b = system(a)
+	res, err = GMRES(ctx, a, b, Settings{Tol: 1e-12, Restart: 20, MaxIter: 5000})
+	checkSolution(t, "GMRES", res, err, x, 1e-8)
+
+	res, err = GMRES(ctx, a.ToCSR(), b, Settings{Tol: 1e-12, MaxIter: 5000, Precond: jac})
+	checkSolution(t, "preconditioned GMRES", res, err, x, 1e-8)
+}
+
+func TestSolverErrors(t *testing.T) {
+	ctx := context.Background()
+	a := poisson(50, 0)
+	_, b := system(a)
+
+	res, err := CG(ctx, a, b, Settings{Tol: 1e-12, MaxIter: 3})
+	var convErr cmath.ConvergenceError
+	if !errors.As(err, &convErr) {
+		t.Errorf("incorrect result: expected ConvergenceError, got %v.", err)
+	}
+	if res.Converged || res.Iterations != 3 || len(res.Residuals) != 4 {
+		t.Errorf("incorrect result: expected 3 iterations without convergence, got %+v.", res)
+	}
+
+	// A breakdown reports the iterations done, not the limit.
+	zero := cmath.StartZerosMatrix(2, 2)
+	_, err = CG(ctx, zero, []float64{1, 1}, Settings{MaxIter: 10})
+	if !errors.As(err, &convErr) || convErr.Iterations != 0 {
+		t.Errorf("incorrect result: expected ConvergenceError after 0 iterations, got %v.", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := GMRES(canceled, a, b, Settings{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("incorrect result: expected context.Canceled, got %v.", err)
+	}
+	if _, err := Jacobi(canceled, a, b, Settings{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("incorrect result: expected context.Canceled, got %v.", err)
+	}
+
+	if _, err := CG(ctx, a, b[:10], Settings{}); !errors.Is(err, cmath.ErrDimension) {
+		t.Errorf("incorrect result: expected ErrDimension, got %v.", err)
+	}
+	rect := cmath.StartZerosMatrix(2, 3)
+	var nsErr cmath.NotSquareError
+	if _, err := GaussSeidel(ctx, rect, []float64{1, 2}, Settings{}); !errors.As(err, &nsErr) {
+		t.Errorf("incorrect result: expected NotSquareError, got %v.", err)
+	}
+}
+
+// cancelAfter is an Operator that cancels its context after n
+// products.
+type cancelAfter struct {
+	Operator
+	n      int
+	cancel context.CancelFunc
+}
+
+func (c *cancelAfter) MulVecTo(dst, x []float64) error {
+	c.n--
+	if c.n == 0 {
+		c.cancel()
+	}
+	return c.Operator.MulVecTo(dst, x)
+}
+
+func TestGMRESPartialCycle(t *testing.T) {
+	a := poisson(50, 0.01)
+	_, b := system(a)
+
+	// The residuals of a canceled cycle are those of the returned X.
+	ctx, cancel := context.WithCancel(context.Background())
+	op := &cancelAfter{Operator: a, n: 5, cancel: cancel}
+	res, err := GMRES(ctx, op, b, Settings{Tol: 1e-12})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("incorrect result: expected context.Canceled, got %v.", err)
+	}
+	r := make([]float64, len(b))
+	got := relNorm(residual(a, res.X, b, r), norm2(b))
+	if last := res.Residuals[res.Iterations]; res.Iterations == 0 || math.Abs(last-got) > 1e-12 {
+		t.Errorf("incorrect result: expected residual %v after %d iterations, got %v.", got, res.Iterations, last)
+	}
+
+	// A singular A without progress stagnates instead of giving NaN.
+	sing, _ := cmath.StartMatrix(2, 2, 0, 1, 0, 0)
+	res, err = GMRES(context.Background(), sing, []float64{1, 0}, Settings{})
+	var convErr cmath.ConvergenceError
+	if !errors.As(err, &convErr) {
+		t.Errorf("incorrect result: expected ConvergenceError, got %v.", err)
+	}
+	for _, v := range res.X {
+		if math.IsNaN(v) {
+			t.Errorf("incorrect result: expected finite solution, got %v.", res.X)
+			break
+		}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Go Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file.
+*/
+
+package csolver
+
+import (
+	"context"
+	"fmt"
+
+	"mycodes/calc/cmath"
+)
+
+// Jacobi solves A*x = b by the Jacobi method, x ← x + M⁻¹(b - A*x),
+// where M is the diagonal of A or s.Precond, if it is set. The
+// method converges for strictly diagonally dominant matrices. A
+// cmath.ConvergenceError is returned, with the partial Result, if
+// the tolerance is not reached, and ctx.Err() if ctx is canceled.
+func Jacobi(ctx context.Context, a cmath.Matrix, b []float64, s Settings) (Result, error) {
+	n, c := a.Dims()
+	x, err := prepare(n, c, b, &s)
+	if err != nil {
+		return Result{}, err
+	}
+
+	precond := s.Precond
+	if precond == nil {
+		jac, err := NewJacobiPrecond(a)
+		if err != nil {
+			return Result{}, err
+		}
+		precond = jac
+	}
+
+	bnorm := norm2(b)
+	r := make([]float64, n)
+	z := make([]float64, n)
+	res := Result{X: x}
+	res.Residuals = append(res.Residuals, relNorm(residual(a, x, b, r), bnorm))
+
+	for res.Iterations < s.MaxIter && res.Residuals[res.Iterations] > s.Tol {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		precond.Apply(z, r)
+		for i := range x {
+			x[i] += z[i]
+		}
+		res.Iterations++
+		res.Residuals = append(res.Residuals, relNorm(residual(a, x, b, r), bnorm))
+	}
+	res.Converged = res.Residuals[res.Iterations] <= s.Tol
+
+	return finish(res, "Jacobi")
+}
+
+// GaussSeidel solves A*x = b by the Gauss-Seidel method, which
+// uses the updated components of x as soon as they are computed.
+// It is SOR with Omega = 1, so s.Omega is ignored. The splitting
+// of A is its own preconditioner, so s.Precond is ignored.
+func GaussSeidel(ctx context.Context, a cmath.Matrix, b []float64, s Settings) (Result, error) {
+	s.Omega = 1.
+	return sor(ctx, a, b, s, "Gauss-Seidel")
+}
+
+// SOR solves A*x = b by the successive over-relaxation method, with
+// the relaxation factor s.Omega. The method converges for symmetric
+// positive-definite matrices when 0 < Omega < 2. The splitting of
+// A is its own preconditioner, so s.Precond is ignored. A
+// cmath.ConvergenceError is returned, with the partial Result, if
+// the tolerance is not reached, and ctx.Err() if ctx is canceled.
+func SOR(ctx context.Context, a cmath.Matrix, b []float64, s Settings) (Result, error) {
+	return sor(ctx, a, b, s, "SOR")
+}
+
+// sor implements GaussSeidel and SOR.
+func sor(ctx context.Context, a cmath.Matrix, b []float64, s Settings, method string) (Result, error) {
+	n, c := a.Dims()
+	x, err := prepare(n, c, b, &s)
+	if err != nil {
+		return Result{}, err
+	}
+	if s.Omega <= 0. || s.Omega >= 2. {
+		return Result{}, fmt.Errorf("SOR relaxation factor %g is outside (0, 2)", s.Omega)
+	}
+
+	rows := make([][]float64, n)
+	for i := range rows {
+		rows[i], _ = a.RowView(i)
+		if rows[i][i] == 0. {
+			return Result{}, fmt.Errorf("zero diagonal element in row %d", i)
+		}
+	}
+
+	bnorm := norm2(b)
+	r := make([]float64, n)
+	res := Result{X: x}
+	res.Residuals = append(res.Residuals, relNorm(residual(a, x, b, r), bnorm))
+
+	for res.Iterations < s.MaxIter && res.Residuals[res.Iterations] > s.Tol {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		for i, row := range rows {
+			// b[i] minus the row times x, without the diagonal term.
+			sum := b[i] - dot(row, x) + row[i]*x[i]
+			x[i] += s.Omega * (sum/row[i] - x[i])
+		}
+		res.Iterations++
+		res.Residuals = append(res.Residuals, relNorm(residual(a, x, b, r), bnorm))
+	}
+	res.Converged = res.Residuals[res.Iterations] <= s.Tol
+
+	return finish(res, method)
+}
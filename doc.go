@@ -11,13 +11,17 @@ with several pending issues and possibilities still open.
 
 # Components
 
-At the moment calc has two modules:
+At the moment calc has three modules:
 
   - cmath - with types, attributes and methods to operate with
-    matrices and vectors;
+    matrices and vectors, including NxN determinants,
+    inverse, adjugate and transpose matrices, eigenvalues,
+    eigenvectors and diagonalization;
   - cnumeric - with statistical functions and calculation of
     roots of polynomials (at the moment only 2nd order plinoms
-    are implemented).
+    are implemented);
+  - csolver - with iterative solvers (Jacobi, Gauss-Seidel, SOR,
+    CG and GMRES) for large linear systems.
 
 The project is still in the embryonic stage and does not have
 user interface, but only in the development of the tools.
@@ -25,13 +29,9 @@ user interface, but only in the development of the tools.
 # Future Additions
 
 The project provides for the addition of the following features:
- 1. cmath: add resolution of eigenvalues ​​and eigenvectors;
- 2. cmath: add matrix, inverse matrix, adjunct and transpose
-    diagonalization.
- 3. cmath: add determinant calculus for NxN matrix.
- 4. calc: add GUI employ go-gtk,
+ 1. calc: add GUI employ go-gtk,
     (https://github.com/mattn/go-gtk)
- 5. calc: implement a numeric expression interpreter to convert
+ 2. calc: implement a numeric expression interpreter to convert
     "(a+b)xc" to "CrossProd(a.add(b),c)"
 */
 package main